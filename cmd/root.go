@@ -13,6 +13,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/ritiksrivastava/archhelix/internal/engine"
 	"github.com/ritiksrivastava/archhelix/internal/orchestrator"
+	"github.com/ritiksrivastava/archhelix/internal/provider"
 	"github.com/spf13/cobra"
 )
 
@@ -30,6 +31,15 @@ var (
 
 	// repoRootPath holds the path to the repository being analyzed
 	repoRootPath string
+
+	// goTypes enables type-checked Go analysis
+	goTypes bool
+
+	// includeDirs are fallback C/C++ include roots
+	includeDirs []string
+
+	// pythonRoots are extra Python source roots
+	pythonRoots []string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -49,12 +59,33 @@ func Execute(assets fs.FS) {
 	}
 }
 
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&goTypes, "go-types", false, "Resolve Go symbols with go/types (slower, needs the go toolchain)")
+	rootCmd.PersistentFlags().StringSliceVar(&includeDirs, "include-dir", nil, "C/C++ include roots used when compile_commands.json does not cover a file")
+	rootCmd.PersistentFlags().StringSliceVar(&pythonRoots, "python-root", nil, "Extra Python source roots that module names are relative to")
+}
+
+// configureProviders applies command-line options to the registered language providers.
+func configureProviders() {
+	provider.Register(".go", &provider.GoProvider{TypeChecked: goTypes})
+
+	cProvider := &provider.CProvider{IncludeDirs: includeDirs}
+	for _, ext := range provider.CExtensions {
+		provider.Register(ext, cProvider)
+	}
+
+	pythonProvider := &provider.PythonProvider{SourceRoots: pythonRoots}
+	provider.Register(".py", pythonProvider)
+	provider.Register(".ipynb", &provider.NotebookProvider{Python: pythonProvider})
+}
+
 func startServer(rootPath string) {
 	if frontendAssets == nil {
 		log.Fatal("Frontend assets not initialized")
 	}
 
 	repoRootPath = rootPath
+	configureProviders()
 
 	// Initialize Engine and Orchestrator
 	eng = engine.New()
@@ -15,12 +15,50 @@ type FileDNA struct {
 	// Language identifies the source language (e.g., "go", "typescript").
 	Language string
 
+	// Kind classifies nodes that are not plain source files (e.g., "project").
+	// It is empty for source files.
+	Kind string
+
 	// Imports contains a list of dependencies imported by this file.
 	Imports []string
 
 	// Exports contains a list of symbols (functions, classes, constants) exported by this file.
 	Exports []string
 
+	// Provides lists additional import paths satisfied by this file, such as the
+	// packages generated from a .proto file. Unlike PackagePath, several files
+	// may provide the same path.
+	Provides []string
+
+	// Contains lists the files this node is made of, such as the srcs of a
+	// build target. Paths are in the same form as Path.
+	Contains []string
+
+	// GroupDir makes this node the group of every other node under that
+	// directory, such as the files of a build module. The nearest enclosing
+	// group wins. It is in the same form as Path.
+	GroupDir string
+
+	// ReExports maps names this module re-exports to the symbols they stand
+	// for, e.g. "Client" -> "pkg.impl.Client" for a package __init__.py doing
+	// `from .impl import Client`. The engine follows them to the defining file.
+	ReExports map[string]string
+
+	// ReExportsAll lists modules all of whose names this module re-exports,
+	// e.g. "pkg.impl" for `from .impl import *`.
+	ReExportsAll []string
+
+	// DynamicImports lists imports made at runtime, such as Python's
+	// importlib.import_module("app.plugins.a"). They are linked as inferred
+	// edges. An entry ending in "*" is a prefix that links every package path
+	// starting with it.
+	DynamicImports []string
+
+	// ImportKinds tags the imports and uses that only hold in some context,
+	// keyed by the import path or used symbol (see ImportType and friends).
+	// Untagged entries are plain dependencies.
+	ImportKinds map[string]string
+
 	// Uses tracks external symbols called/used in this file (e.g., "fmt.Println", "server.NewServer").
 	Uses []string
 
@@ -30,3 +68,35 @@ type FileDNA struct {
 	// DependencyCount is the number of incoming dependencies (useful for UI sizing/gravity).
 	DependencyCount int
 }
+
+// Import kinds tag dependencies that don't hold unconditionally at load time.
+// They are carried onto the graph edges they produce.
+const (
+	ImportLazy     = "lazy"     // imported when a function runs
+	ImportOptional = "optional" // imported with a fallback if missing
+	ImportDynamic  = "dynamic"  // imported by a module name computed at runtime
+	ImportType     = "type"     // imported for type checking only
+	ImportBarrel   = "barrel"   // re-exports an imported symbol defined elsewhere
+)
+
+// importKindStrength orders import kinds from the strongest dependency (a
+// plain import) to the weakest.
+var importKindStrength = map[string]int{"": 4, ImportLazy: 3, ImportOptional: 2, ImportDynamic: 1, ImportType: 0, ImportBarrel: -1}
+
+// StrongerImportKind returns whichever of two import kinds is the stronger
+// dependency, e.g. a plain import over a type-only one.
+func StrongerImportKind(a, b string) string {
+	if importKindStrength[b] > importKindStrength[a] {
+		return b
+	}
+	return a
+}
+
+// WeakerImportKind returns whichever of two import kinds is the weaker
+// dependency, e.g. for a lazy import under a type-checking guard.
+func WeakerImportKind(a, b string) string {
+	if StrongerImportKind(a, b) == a {
+		return b
+	}
+	return a
+}
@@ -1,7 +1,10 @@
 package engine
 
 import (
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/ritiksrivastava/archhelix/internal/core"
@@ -13,6 +16,10 @@ type Engine struct {
 	mu          sync.RWMutex
 	SymbolTable map[string]string        // Maps symbol/package names to the defining file path.
 	FileMap     map[string]*core.FileDNA // Maps file path to its parsed DNA.
+	Packages    map[string][]string      // Maps package paths to every file declaring them.
+	Providers   map[string][]string      // Maps import paths to files that provide them (see FileDNA.Provides).
+	ReExports   map[string]string        // Maps re-exported symbols to the symbols they stand for (see FileDNA.ReExports).
+	ReExportAll map[string][]string      // Maps package paths to the modules they re-export wholesale.
 	Graph       *graph.Graph
 }
 
@@ -21,6 +28,10 @@ func New() *Engine {
 	return &Engine{
 		SymbolTable: make(map[string]string),
 		FileMap:     make(map[string]*core.FileDNA),
+		Packages:    make(map[string][]string),
+		Providers:   make(map[string][]string),
+		ReExports:   make(map[string]string),
+		ReExportAll: make(map[string][]string),
 		Graph:       &graph.Graph{Nodes: []graph.Node{}, Edges: []graph.Edge{}},
 	}
 }
@@ -34,7 +45,7 @@ func (e *Engine) IngestFileDNA(dna *core.FileDNA) {
 	e.FileMap[dna.Path] = dna
 
 	// Add node to graph
-	node := graph.Node{ID: dna.Path, Label: filepath.Base(dna.Path)}
+	node := graph.Node{ID: dna.Path, Label: filepath.Base(dna.Path), Kind: dna.Kind}
 	e.Graph.Nodes = append(e.Graph.Nodes, node)
 
 	// Update Symbol Table with exports
@@ -47,9 +58,24 @@ func (e *Engine) IngestFileDNA(dna *core.FileDNA) {
 		e.SymbolTable[export] = dna.Path
 	}
 
+	if dna.PackagePath != "" {
+		// Re-exported names resolve to the re-exporting file until linked
+		// through to their definition.
+		for name, target := range dna.ReExports {
+			e.SymbolTable[dna.PackagePath+"."+name] = dna.Path
+			e.ReExports[dna.PackagePath+"."+name] = target
+		}
+		e.ReExportAll[dna.PackagePath] = append(e.ReExportAll[dna.PackagePath], dna.ReExportsAll...)
+	}
+
+	for _, provided := range dna.Provides {
+		e.Providers[provided] = append(e.Providers[provided], dna.Path)
+	}
+
 	// Register the package path itself to map to the file (last file wins for package-level imports)
 	if dna.PackagePath != "" {
 		e.SymbolTable[dna.PackagePath] = dna.Path
+		e.Packages[dna.PackagePath] = append(e.Packages[dna.PackagePath], dna.Path)
 	} else {
 		e.SymbolTable[dna.Package] = dna.Path
 	}
@@ -60,30 +86,98 @@ func (e *Engine) LinkDependencies() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	var packagePaths []string // sorted keys of e.Packages, for prefix imports
 	for _, dna := range e.FileMap {
-		seen := make(map[string]bool)
+		seen := make(map[string]int) // target -> index of its edge
+		linkedPackages := make(map[string]bool)
+		var linkedUses []string
+
+		// addEdge links a target once, keeping the strongest kind when it is
+		// reached through several imports (e.g. both type-only and plain). An
+		// edge is only inferred if every import reaching it is.
+		addEdge := func(targetPath, kind string, inferred bool) {
+			if targetPath == dna.Path {
+				return
+			}
+			if i, ok := seen[targetPath]; ok {
+				edge := &e.Graph.Edges[i]
+				edge.Kind = core.StrongerImportKind(edge.Kind, kind)
+				edge.Inferred = edge.Inferred && inferred
+				return
+			}
+			seen[targetPath] = len(e.Graph.Edges)
+			e.Graph.Edges = append(e.Graph.Edges, graph.Edge{Source: targetPath, Target: dna.Path, Kind: kind, Inferred: inferred})
+		}
 
 		// 1. Link based on specific symbol usages (Granular)
 		for _, use := range dna.Uses {
-			if targetPath, ok := e.SymbolTable[use]; ok {
-				if targetPath != dna.Path && !seen[targetPath] {
-					edge := graph.Edge{Source: targetPath, Target: dna.Path}
-					e.Graph.Edges = append(e.Graph.Edges, edge)
-					seen[targetPath] = true
+			if targetPath, barrels, ok := e.resolveSymbol(use); ok {
+				if target, ok := e.FileMap[targetPath]; ok && target.PackagePath != "" {
+					linkedPackages[target.PackagePath] = true
+				}
+				linkedUses = append(linkedUses, use)
+				addEdge(targetPath, dna.ImportKinds[use], false)
+				for _, barrel := range barrels {
+					addEdge(barrel, core.ImportBarrel, false)
 				}
 			}
 		}
 
 		// 2. Link based on package-level imports (Broad)
 		for _, imp := range dna.Imports {
+			// A package spread over several files (a Go package, a C# namespace)
+			// maps to whichever file was ingested last. If the granular pass
+			// already found the files actually used, don't add that arbitrary one.
+			if linkedPackages[imp] && len(e.Packages[imp]) > 1 {
+				continue
+			}
+			// Likewise, a module imported only to reach into it (Python's
+			// `import pkg` then `pkg.sub.fn()`) is covered by the symbols used.
+			if usesUnder(linkedUses, imp) {
+				continue
+			}
 			// Check if import matches a known package
-			if targetPath, ok := e.SymbolTable[imp]; ok {
-				// Avoid self-loops and duplicates
-				if targetPath != dna.Path && !seen[targetPath] {
-					edge := graph.Edge{Source: targetPath, Target: dna.Path}
-					e.Graph.Edges = append(e.Graph.Edges, edge)
-					seen[targetPath] = true
+			if targetPath, barrels, ok := e.resolveSymbol(imp); ok {
+				addEdge(targetPath, dna.ImportKinds[imp], false)
+				for _, barrel := range barrels {
+					addEdge(barrel, core.ImportBarrel, false)
+				}
+			}
+		}
+
+		// 3. Link files that provide an imported path (e.g. the .proto behind generated code)
+		for _, imp := range dna.Imports {
+			for _, targetPath := range e.Providers[imp] {
+				addEdge(targetPath, dna.ImportKinds[imp], false)
+			}
+		}
+
+		// 4. Link runtime imports, fanning prefixes out to every matching package
+		for _, imp := range dna.DynamicImports {
+			prefix, ok := strings.CutSuffix(imp, "*")
+			if !ok {
+				if targetPath, ok := e.SymbolTable[imp]; ok {
+					addEdge(targetPath, dna.ImportKinds[imp], true)
 				}
+				continue
+			}
+			if packagePaths == nil {
+				packagePaths = sortedPackagePaths(e.Packages)
+			}
+			for _, packagePath := range packagePaths {
+				if strings.HasPrefix(packagePath, prefix) {
+					for _, targetPath := range e.Packages[packagePath] {
+						addEdge(targetPath, dna.ImportKinds[imp], true)
+					}
+				}
+			}
+		}
+
+		// 5. Link the files a node is made of (e.g. the srcs of a build target)
+		for _, file := range dna.Contains {
+			if _, ok := e.FileMap[file]; ok && file != dna.Path {
+				edge := graph.Edge{Source: file, Target: dna.Path, Kind: "contains"}
+				e.Graph.Edges = append(e.Graph.Edges, edge)
 			}
 		}
 	}
@@ -94,11 +188,20 @@ func (e *Engine) LinkDependencies() {
 		dependencyCounts[edge.Source]++
 	}
 
-	// Update Graph.Nodes and FileMap with the calculated counts
+	// Nodes that declare a GroupDir (e.g. build modules) group everything under it
+	groups := make(map[string]string)
+	for _, dna := range e.FileMap {
+		if dna.GroupDir != "" {
+			groups[dna.GroupDir] = dna.Path
+		}
+	}
+
+	// Update Graph.Nodes and FileMap with the calculated counts and groups
 	for i := range e.Graph.Nodes {
 		nodeID := e.Graph.Nodes[i].ID
 		count := dependencyCounts[nodeID]
 		e.Graph.Nodes[i].DependencyCount = count
+		e.Graph.Nodes[i].Group = groupOf(nodeID, groups)
 
 		if dna, ok := e.FileMap[nodeID]; ok {
 			dna.DependencyCount = count
@@ -106,6 +209,96 @@ func (e *Engine) LinkDependencies() {
 	}
 }
 
+// resolveSymbol looks up the file defining a symbol, following re-exports
+// (e.g. `from pkg import Client` re-exported by pkg/__init__.py) to the file
+// that defines it. It also returns the re-exporting files passed through. A
+// chain leaving the repository ends at the last re-exporting file.
+func (e *Engine) resolveSymbol(symbol string) (string, []string, bool) {
+	var barrels []string
+	for visited := make(map[string]bool); !visited[symbol]; {
+		visited[symbol] = true
+		target, ok := e.reExportOf(symbol)
+		if !ok {
+			break
+		}
+		if barrel, ok := e.SymbolTable[symbol[:strings.LastIndex(symbol, ".")]]; ok {
+			barrels = append(barrels, barrel)
+		}
+		symbol = target
+	}
+
+	targetPath, ok := e.SymbolTable[symbol]
+	if !ok && len(barrels) > 0 {
+		return barrels[len(barrels)-1], barrels[:len(barrels)-1], true
+	}
+	return targetPath, barrels, ok
+}
+
+// reExportOf returns the symbol a re-exported symbol stands for, checking
+// the modules re-exported wholesale by its package last.
+func (e *Engine) reExportOf(symbol string) (string, bool) {
+	if target, ok := e.ReExports[symbol]; ok {
+		return target, true
+	}
+	dot := strings.LastIndex(symbol, ".")
+	if dot == -1 {
+		return "", false
+	}
+	if _, ok := e.SymbolTable[symbol]; ok {
+		// Defined in the package itself, e.g. a submodule.
+		return "", false
+	}
+	modules := e.ReExportAll[symbol[:dot]]
+	for _, module := range modules {
+		candidate := module + symbol[dot:]
+		if _, ok := e.SymbolTable[candidate]; ok {
+			return candidate, true
+		}
+	}
+	// The name may be re-exported further down a chain of wildcards.
+	for _, module := range modules {
+		if len(e.ReExportAll[module]) > 0 {
+			return module + symbol[dot:], true
+		}
+	}
+	return "", false
+}
+
+// usesUnder reports whether any of the uses is a symbol inside the import path.
+func usesUnder(uses []string, imp string) bool {
+	for _, use := range uses {
+		if strings.HasPrefix(use, imp+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedPackagePaths(packages map[string][]string) []string {
+	paths := make([]string, 0, len(packages))
+	for packagePath := range packages {
+		paths = append(paths, packagePath)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// groupOf returns the group of the nearest directory above a node, skipping
+// the node's own group.
+func groupOf(nodeID string, groups map[string]string) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	for dir := path.Dir(nodeID); ; dir = path.Dir(dir) {
+		if group, ok := groups[dir]; ok && group != nodeID {
+			return group
+		}
+		if dir == "." || dir == "/" {
+			return ""
+		}
+	}
+}
+
 // GetGraph returns the current state of the graph.
 func (e *Engine) GetGraph() *graph.Graph {
 	e.mu.RLock()
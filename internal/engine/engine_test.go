@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	"github.com/ritiksrivastava/archhelix/internal/graph"
+)
+
+func hasEdge(e *Engine, source, target string) bool {
+	for _, edge := range e.GetGraph().Edges {
+		if edge.Source == source && edge.Target == target {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLinkDependenciesMultiFilePackage(t *testing.T) {
+	e := New()
+	e.IngestFileDNA(&core.FileDNA{Path: "core/Money.cs", PackagePath: "Acme.Core", Exports: []string{"Money"}})
+	e.IngestFileDNA(&core.FileDNA{Path: "core/Clock.cs", PackagePath: "Acme.Core", Exports: []string{"Clock"}})
+	e.IngestFileDNA(&core.FileDNA{
+		Path:        "billing/Invoice.cs",
+		PackagePath: "Acme.Billing",
+		Imports:     []string{"Acme.Core"},
+		Uses:        []string{"Acme.Core.Money"},
+	})
+	e.IngestFileDNA(&core.FileDNA{
+		Path:        "billing/Report.cs",
+		PackagePath: "Acme.Billing",
+		Imports:     []string{"Acme.Core"},
+	})
+	e.LinkDependencies()
+
+	if !hasEdge(e, "core/Money.cs", "billing/Invoice.cs") {
+		t.Errorf("expected granular edge Money.cs -> Invoice.cs")
+	}
+	if hasEdge(e, "core/Clock.cs", "billing/Invoice.cs") {
+		t.Errorf("namespace import should not collapse onto the last ingested file")
+	}
+	// Without resolved usages the package-level import is still linked.
+	if !hasEdge(e, "core/Clock.cs", "billing/Report.cs") {
+		t.Errorf("expected broad edge for package import without usages")
+	}
+}
+
+func TestLinkDependenciesContains(t *testing.T) {
+	e := New()
+	e.IngestFileDNA(&core.FileDNA{Path: "app/main.go", PackagePath: "example.com/app"})
+	e.IngestFileDNA(&core.FileDNA{
+		Path:        "app/BUILD.bazel/app",
+		PackagePath: "bazel://app:app",
+		Kind:        "target",
+		Contains:    []string{"app/main.go", "app/missing.go"},
+	})
+	e.LinkDependencies()
+
+	edges := e.GetGraph().Edges
+	if len(edges) != 1 || edges[0].Source != "app/main.go" || edges[0].Kind != "contains" {
+		t.Errorf("expected a single contains edge from app/main.go, got %+v", edges)
+	}
+}
+
+func TestLinkDependenciesGroups(t *testing.T) {
+	e := New()
+	e.IngestFileDNA(&core.FileDNA{Path: "pom.xml", Kind: "module", GroupDir: "."})
+	e.IngestFileDNA(&core.FileDNA{Path: "api/pom.xml", Kind: "module", GroupDir: "api"})
+	e.IngestFileDNA(&core.FileDNA{Path: "api/src/Api.java"})
+	e.IngestFileDNA(&core.FileDNA{Path: "README.md"})
+	e.LinkDependencies()
+
+	want := map[string]string{
+		"pom.xml":          "",
+		"api/pom.xml":      "pom.xml",
+		"api/src/Api.java": "api/pom.xml",
+		"README.md":        "pom.xml",
+	}
+	for _, node := range e.GetGraph().Nodes {
+		if node.Group != want[node.ID] {
+			t.Errorf("node %s: expected group %q, got %q", node.ID, want[node.ID], node.Group)
+		}
+	}
+}
+
+func TestLinkDependenciesImportKinds(t *testing.T) {
+	e := New()
+	e.IngestFileDNA(&core.FileDNA{Path: "app/models.py", PackagePath: "app.models", Exports: []string{"User"}})
+	e.IngestFileDNA(&core.FileDNA{Path: "app/db.py", PackagePath: "app.db"})
+	e.IngestFileDNA(&core.FileDNA{
+		Path:        "app/views.py",
+		PackagePath: "app.views",
+		Imports:     []string{"app.models.User", "app.db"},
+		Uses:        []string{"app.models.User"},
+		ImportKinds: map[string]string{"app.models.User": core.ImportType, "app.db": core.ImportLazy},
+	})
+	e.IngestFileDNA(&core.FileDNA{
+		Path:        "app/admin.py",
+		PackagePath: "app.admin",
+		Imports:     []string{"app.models", "app.models.User"},
+		ImportKinds: map[string]string{"app.models.User": core.ImportType},
+	})
+	e.LinkDependencies()
+
+	kinds := make(map[string]string)
+	for _, edge := range e.GetGraph().Edges {
+		kinds[edge.Source+" -> "+edge.Target] = edge.Kind
+	}
+	want := map[string]string{
+		"app/models.py -> app/views.py": core.ImportType,
+		"app/db.py -> app/views.py":     core.ImportLazy,
+		// A plain import of the same file outranks the type-only one.
+		"app/models.py -> app/admin.py": "",
+	}
+	for edge, kind := range want {
+		if got, ok := kinds[edge]; !ok || got != kind {
+			t.Errorf("expected edge %s of kind %q, got %q (present: %v)", edge, kind, got, ok)
+		}
+	}
+}
+
+func TestLinkDependenciesDynamicImports(t *testing.T) {
+	e := New()
+	e.IngestFileDNA(&core.FileDNA{Path: "app/plugins/__init__.py", PackagePath: "app.plugins"})
+	e.IngestFileDNA(&core.FileDNA{Path: "app/plugins/a.py", PackagePath: "app.plugins.a"})
+	e.IngestFileDNA(&core.FileDNA{Path: "app/plugins/b.py", PackagePath: "app.plugins.b"})
+	e.IngestFileDNA(&core.FileDNA{Path: "app/config.py", PackagePath: "app.config"})
+	e.IngestFileDNA(&core.FileDNA{
+		Path:           "app/loader.py",
+		PackagePath:    "app.loader",
+		Imports:        []string{"app.plugins.a"},
+		DynamicImports: []string{"app.config", "app.plugins.*"},
+		ImportKinds:    map[string]string{"app.plugins.*": core.ImportDynamic},
+	})
+	e.LinkDependencies()
+
+	edges := make(map[string]graph.Edge)
+	for _, edge := range e.GetGraph().Edges {
+		edges[edge.Source] = edge
+	}
+	want := map[string]graph.Edge{
+		"app/config.py":    {Kind: "", Inferred: true},
+		"app/plugins/b.py": {Kind: core.ImportDynamic, Inferred: true},
+		// Also imported statically, so neither inferred nor dynamic.
+		"app/plugins/a.py": {Kind: "", Inferred: false},
+	}
+	for source, w := range want {
+		edge, ok := edges[source]
+		if !ok || edge.Kind != w.Kind || edge.Inferred != w.Inferred {
+			t.Errorf("%s: expected kind %q inferred %v, got %+v", source, w.Kind, w.Inferred, edge)
+		}
+	}
+	if _, ok := edges["app/plugins/__init__.py"]; ok {
+		t.Errorf("the prefix should not match the package itself")
+	}
+}
+
+func TestLinkDependenciesReExports(t *testing.T) {
+	e := New()
+	e.IngestFileDNA(&core.FileDNA{Path: "pkg/impl.py", PackagePath: "pkg.impl", Exports: []string{"Client"}})
+	e.IngestFileDNA(&core.FileDNA{Path: "pkg/models/user.py", PackagePath: "pkg.models.user", Exports: []string{"User"}})
+	e.IngestFileDNA(&core.FileDNA{Path: "pkg/models/__init__.py", PackagePath: "pkg.models", ReExportsAll: []string{"pkg.models.user"}})
+	e.IngestFileDNA(&core.FileDNA{Path: "pkg/sub.py", PackagePath: "pkg.sub"})
+	e.IngestFileDNA(&core.FileDNA{
+		Path:         "pkg/__init__.py",
+		PackagePath:  "pkg",
+		ReExports:    map[string]string{"Client": "pkg.impl.Client"},
+		ReExportsAll: []string{"pkg.models"},
+	})
+	e.IngestFileDNA(&core.FileDNA{
+		Path:        "app/main.py",
+		PackagePath: "app.main",
+		Imports:     []string{"pkg", "pkg.Client", "pkg.User", "pkg.sub"},
+		Uses:        []string{"pkg.Client", "pkg.User"},
+	})
+	e.LinkDependencies()
+
+	kinds := make(map[string]string)
+	for _, edge := range e.GetGraph().Edges {
+		if edge.Target == "app/main.py" {
+			kinds[edge.Source] = edge.Kind
+		}
+	}
+	want := map[string]string{
+		"pkg/impl.py":            "",
+		"pkg/models/user.py":     "",
+		"pkg/sub.py":             "",
+		"pkg/__init__.py":        core.ImportBarrel,
+		"pkg/models/__init__.py": core.ImportBarrel,
+	}
+	if !maps.Equal(kinds, want) {
+		t.Errorf("expected edges %v, got %v", want, kinds)
+	}
+}
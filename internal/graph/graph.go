@@ -8,10 +8,20 @@ type Graph struct {
 type Node struct {
 	ID              string
 	Label           string
+	Kind            string
+	Group           string // ID of the node grouping this one (see FileDNA.GroupDir).
 	DependencyCount int
 }
 
 type Edge struct {
 	Source string
 	Target string
+	// Kind distinguishes edges that are not plain dependencies: "contains"
+	// from a source file to the build target it belongs to, or the kind of a
+	// conditional import (see core.ImportType and friends). Empty for plain
+	// dependencies.
+	Kind string
+	// Inferred marks edges guessed from runtime behaviour, such as dynamic
+	// imports, rather than declared in the source.
+	Inferred bool
 }
@@ -46,21 +46,32 @@ func (o *Orchestrator) Start(root string) error {
 			for path := range jobs {
 				ext := filepath.Ext(path)
 				fmt.Println("Processing", path, "with extension", ext)
-				// Check if we have a provider for this extension
-				if p, ok := provider.Get(ext); ok {
-					dna, err := p.ParseFile(path)
-					if err == nil && dna != nil {
+				// Check if we have a provider for this file name or extension
+				if p, ok := provider.ForFile(path); ok {
+					nodes, err := parseNodes(p, path)
+					if err != nil {
+						// Log error but continue
+						fmt.Fprintf(os.Stderr, "Error parsing %s: %v\n", path, err)
+					}
+					// No nodes without an error means the provider intentionally skipped the file (e.g., a test file).
+					for _, dna := range nodes {
 						// Normalize path to relative path
 						relPath, relErr := filepath.Rel(root, dna.Path)
 						if relErr == nil {
 							dna.Path = filepath.ToSlash(relPath)
 						}
+						if dna.GroupDir != "" {
+							if relDir, relErr := filepath.Rel(root, dna.GroupDir); relErr == nil {
+								dna.GroupDir = filepath.ToSlash(relDir)
+							}
+						}
+						for i, file := range dna.Contains {
+							if relFile, relErr := filepath.Rel(root, file); relErr == nil {
+								dna.Contains[i] = filepath.ToSlash(relFile)
+							}
+						}
 						results <- dna
-					} else if err != nil {
-						// Log error but continue
-						fmt.Fprintf(os.Stderr, "Error parsing %s: %v\n", path, err)
 					}
-					// If err == nil && dna == nil, it means the provider intentionally skipped the file (e.g., a test file).
 				}
 			}
 		}()
@@ -107,3 +118,16 @@ func (o *Orchestrator) Start(root string) error {
 
 	return nil
 }
+
+// parseNodes runs the provider on a file, returning every node it declares.
+// Most files are a single node; a MultiProvider may return several.
+func parseNodes(p provider.Provider, path string) ([]*core.FileDNA, error) {
+	if mp, ok := p.(provider.MultiProvider); ok {
+		return mp.ParseFileNodes(path)
+	}
+	dna, err := p.ParseFile(path)
+	if err != nil || dna == nil {
+		return nil, err
+	}
+	return []*core.FileDNA{dna}, nil
+}
@@ -0,0 +1,321 @@
+package provider
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+)
+
+// BazelProvider implements the MultiProvider interface for Bazel BUILD files.
+//
+// Starlark is parsed with the Python grammar. Every rule call with a `name`
+// becomes a target node keyed by its label within the workspace. Targets
+// import the labels in their deps, and list their srcs (with glob() calls
+// expanded) in FileDNA.Contains, which links them to the file nodes of the
+// language providers.
+type BazelProvider struct {
+	mu         sync.Mutex
+	workspaces map[string]string // directory -> enclosing workspace root ("" if none)
+}
+
+// Ensure BazelProvider implements MultiProvider.
+var _ MultiProvider = (*BazelProvider)(nil)
+
+// bazelDepsAttributes are the rule attributes holding target dependencies.
+var bazelDepsAttributes = map[string]bool{"deps": true, "runtime_deps": true, "implementation_deps": true, "exports": true}
+
+// ParseFile summarises a BUILD file as a single node exporting its targets.
+func (p *BazelProvider) ParseFile(path string) (*core.FileDNA, error) {
+	nodes, err := p.ParseFileNodes(path)
+	if err != nil {
+		return nil, err
+	}
+	return fileSummary(path, "starlark", "package", nodes), nil
+}
+
+func (p *BazelProvider) ParseFileNodes(path string) ([]*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	absDir := filepath.Dir(absPath)
+
+	// Without a WORKSPACE/MODULE.bazel, treat the BUILD file's directory as
+	// the root so that at least same-package labels resolve.
+	workspace := p.workspaceRoot(absDir)
+	if workspace == "" {
+		workspace = absDir
+	}
+	pkg, _ := filepath.Rel(workspace, absDir)
+	pkg = filepath.ToSlash(pkg)
+	if pkg == "." {
+		pkg = ""
+	}
+	prefix := "bazel:" + filepath.ToSlash(workspace)
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(python.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	var nodes []*core.FileDNA
+	root := tree.RootNode()
+	for i := 0; i < int(root.ChildCount()); i++ {
+		stmt := root.Child(i)
+		if stmt.Type() != "expression_statement" || stmt.ChildCount() == 0 || stmt.Child(0).Type() != "call" {
+			continue
+		}
+		call := stmt.Child(0)
+		args := call.ChildByFieldName("arguments")
+		if args == nil {
+			continue
+		}
+
+		attrs := make(map[string]*sitter.Node)
+		for j := 0; j < int(args.ChildCount()); j++ {
+			if kw := args.Child(j); kw.Type() == "keyword_argument" {
+				if name := kw.ChildByFieldName("name"); name != nil {
+					attrs[name.Content(content)] = kw.ChildByFieldName("value")
+				}
+			}
+		}
+		nameValues := starlarkStrings(attrs["name"], content, "")
+		if len(nameValues) != 1 {
+			continue
+		}
+		name := nameValues[0]
+		key := prefix + "//" + pkg + ":" + name
+
+		dna := &core.FileDNA{
+			Path:        path + "/" + name,
+			Language:    "starlark",
+			Kind:        "target",
+			Package:     name,
+			PackagePath: key,
+			Imports:     []string{},
+			Exports:     []string{},
+			Metadata:    map[string]interface{}{"rule": call.ChildByFieldName("function").Content(content), "label": "//" + pkg + ":" + name},
+		}
+
+		seen := make(map[string]bool)
+		contained := make(map[string]bool)
+		for _, attr := range sortedKeys(attrs) {
+			value := attrs[attr]
+			switch {
+			case bazelDepsAttributes[attr]:
+				for _, label := range starlarkStrings(value, content, "") {
+					dna.Imports = appendUnique(dna.Imports, seen, bazelLabelKey(prefix, pkg, label))
+				}
+			case attr == "srcs" || attr == "hdrs":
+				for _, src := range starlarkStrings(value, content, absDir) {
+					if strings.HasPrefix(src, ":") || strings.HasPrefix(src, "//") || strings.HasPrefix(src, "@") {
+						// A label in srcs is the output of another target.
+						dna.Imports = appendUnique(dna.Imports, seen, bazelLabelKey(prefix, pkg, src))
+					} else {
+						dna.Contains = appendUnique(dna.Contains, contained, filepath.Join(filepath.Dir(path), filepath.FromSlash(src)))
+					}
+				}
+			}
+		}
+		nodes = append(nodes, dna)
+	}
+	return nodes, nil
+}
+
+// bazelLabelKey resolves a label relative to pkg into a target key. Labels of
+// external repositories are returned as written.
+func bazelLabelKey(prefix, pkg, label string) string {
+	label = strings.TrimPrefix(label, "@//")
+	if strings.HasPrefix(label, "@") {
+		return label
+	}
+	if !strings.Contains(label, "//") {
+		// ":name" or "name" within the same package.
+		return prefix + "//" + pkg + ":" + strings.TrimPrefix(label, ":")
+	}
+	label = strings.TrimPrefix(label, "//")
+	if !strings.Contains(label, ":") {
+		// "//pkg/foo" is shorthand for "//pkg/foo:foo".
+		label += ":" + filepath.Base(label)
+	}
+	return prefix + "//" + label
+}
+
+// starlarkStrings flattens a Starlark value made of strings, lists and
+// concatenations into its string elements. When dir is set, glob() calls are
+// expanded against it; otherwise they are ignored.
+func starlarkStrings(node *sitter.Node, sourceCode []byte, dir string) []string {
+	if node == nil {
+		return nil
+	}
+	switch node.Type() {
+	case "string":
+		if str := childOfType(node, "string_content"); str != nil {
+			return []string{str.Content(sourceCode)}
+		}
+	case "list", "binary_operator", "parenthesized_expression":
+		var values []string
+		for i := 0; i < int(node.ChildCount()); i++ {
+			values = append(values, starlarkStrings(node.Child(i), sourceCode, dir)...)
+		}
+		return values
+	case "call":
+		if fn := node.ChildByFieldName("function"); dir != "" && fn != nil && fn.Content(sourceCode) == "glob" {
+			return starlarkGlob(node.ChildByFieldName("arguments"), sourceCode, dir)
+		}
+	}
+	return nil
+}
+
+// starlarkGlob expands glob(include, exclude = [...]) relative to dir. Like
+// Bazel, it does not descend into subpackages (directories with a BUILD file).
+func starlarkGlob(args *sitter.Node, sourceCode []byte, dir string) []string {
+	if args == nil {
+		return nil
+	}
+	var include, exclude []*regexp.Regexp
+	for i := 0; i < int(args.ChildCount()); i++ {
+		arg := args.Child(i)
+		switch arg.Type() {
+		case "list":
+			include = append(include, globPatterns(starlarkStrings(arg, sourceCode, ""))...)
+		case "keyword_argument":
+			patterns := globPatterns(starlarkStrings(arg.ChildByFieldName("value"), sourceCode, ""))
+			switch arg.ChildByFieldName("name").Content(sourceCode) {
+			case "include":
+				include = append(include, patterns...)
+			case "exclude":
+				exclude = append(exclude, patterns...)
+			}
+		}
+	}
+
+	matches := func(patterns []*regexp.Regexp, rel string) bool {
+		for _, pattern := range patterns {
+			if pattern.MatchString(rel) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var files []string
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != dir && isBazelPackage(path) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		rel = filepath.ToSlash(rel)
+		if matches(include, rel) && !matches(exclude, rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files
+}
+
+// globPatterns compiles Bazel glob patterns, where "**" spans any number of
+// directories and "*" stays within one.
+func globPatterns(patterns []string) []*regexp.Regexp {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		var b strings.Builder
+		b.WriteString("^")
+		for i := 0; i < len(pattern); i++ {
+			switch {
+			case strings.HasPrefix(pattern[i:], "**/"):
+				b.WriteString("(.*/)?")
+				i += 2
+			case strings.HasPrefix(pattern[i:], "**"):
+				b.WriteString(".*")
+				i++
+			case pattern[i] == '*':
+				b.WriteString("[^/]*")
+			case pattern[i] == '?':
+				b.WriteString("[^/]")
+			default:
+				b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+			}
+		}
+		b.WriteString("$")
+		if re, err := regexp.Compile(b.String()); err == nil {
+			compiled = append(compiled, re)
+		}
+	}
+	return compiled
+}
+
+func isBazelPackage(dir string) bool {
+	for _, name := range []string{"BUILD", "BUILD.bazel"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// workspaceRoot returns the nearest directory at or above dir holding a
+// MODULE.bazel, WORKSPACE or WORKSPACE.bazel file.
+func (p *BazelProvider) workspaceRoot(dir string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.workspaces == nil {
+		p.workspaces = make(map[string]string)
+	}
+
+	var visited []string
+	root := ""
+	for current := dir; ; {
+		if cached, ok := p.workspaces[current]; ok {
+			root = cached
+			break
+		}
+		visited = append(visited, current)
+		found := false
+		for _, marker := range []string{"MODULE.bazel", "WORKSPACE", "WORKSPACE.bazel"} {
+			if _, err := os.Stat(filepath.Join(current, marker)); err == nil {
+				found = true
+				break
+			}
+		}
+		if found {
+			root = current
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	for _, d := range visited {
+		p.workspaces[d] = root
+	}
+	return root
+}
+
+func init() {
+	p := &BazelProvider{}
+	RegisterName("BUILD", p)
+	RegisterName("BUILD.bazel", p)
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+func TestBazelProviderTargets(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"MODULE.bazel": "module(name = \"shop\")\n",
+		"base/BUILD": `cc_library(
+    name = "base",
+    srcs = glob(["**/*.cc"], exclude = ["**/*_test.cc"]),
+    hdrs = ["base.h"] + glob(["impl/*.h"]),
+    visibility = ["//visibility:public"],
+)
+`,
+		"base/base.cc":       "",
+		"base/base_test.cc":  "",
+		"base/base.h":        "",
+		"base/impl/fast.cc":  "",
+		"base/impl/fast.h":   "",
+		"base/sub/BUILD":     "",
+		"base/sub/nested.cc": "",
+		"app/BUILD.bazel": `load("@rules_cc//cc:defs.bzl", "cc_binary")
+
+genrule(
+    name = "version",
+    outs = ["version.h"],
+    cmd = "echo > $@",
+)
+
+cc_binary(
+    name = "app",
+    srcs = ["main.cc", ":version"],
+    deps = ["//base", "@abseil//absl/strings"],
+)
+
+cc_test(
+    name = "app_test",
+    deps = [":app"],
+)
+`,
+		"app/main.cc": "",
+	})
+
+	p := &BazelProvider{}
+	eng := engine.New()
+	for _, name := range []string{"base/BUILD", "app/BUILD.bazel"} {
+		nodes, err := p.ParseFileNodes(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("ParseFileNodes(%s) failed: %v", name, err)
+		}
+		for _, dna := range nodes {
+			eng.IngestFileDNA(dna)
+		}
+	}
+	eng.LinkDependencies()
+
+	base := eng.FileMap[filepath.Join(root, "base/BUILD/base")]
+	if base == nil || base.Metadata["label"] != "//base:base" {
+		t.Fatalf("expected target //base:base, got %+v", base)
+	}
+	var want []string
+	for _, src := range []string{"base/base.cc", "base/impl/fast.cc", "base/base.h", "base/impl/fast.h"} {
+		want = append(want, filepath.Join(root, src))
+	}
+	slices.Sort(want)
+	got := slices.Sorted(slices.Values(base.Contains))
+	if !slices.Equal(got, want) {
+		t.Errorf("expected srcs and hdrs %v (tests and subpackages excluded), got %v", want, got)
+	}
+
+	app := eng.FileMap[filepath.Join(root, "app/BUILD.bazel/app")]
+	if !slices.Contains(app.Imports, "@abseil//absl/strings") {
+		t.Errorf("expected external labels kept as written, got %v", app.Imports)
+	}
+
+	for _, edge := range [][2]string{
+		{"base/BUILD/base", "app/BUILD.bazel/app"},         // //pkg shorthand
+		{"app/BUILD.bazel/version", "app/BUILD.bazel/app"}, // :name in srcs
+		{"app/BUILD.bazel/app", "app/BUILD.bazel/app_test"},
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/c"
+	"github.com/smacker/go-tree-sitter/cpp"
+)
+
+// CProvider implements the Provider interface for C and C++ files.
+//
+// Every source and header file becomes a node keyed by its absolute path, and
+// each #include that resolves to a file in the repository becomes an import of
+// that path. Include directories are read from the nearest compile_commands.json;
+// IncludeDirs is used for files the compilation database does not cover.
+type CProvider struct {
+	// IncludeDirs are fallback include roots, e.g. from the --include-dir flag.
+	IncludeDirs []string
+
+	mu        sync.Mutex
+	databases map[string]*compileDatabase // directory -> database found from it (nil if none)
+}
+
+// compileDatabase holds the include directories of a compile_commands.json.
+type compileDatabase struct {
+	files map[string][]string // absolute source path -> include directories
+	all   []string            // union of include directories across all entries
+}
+
+// compileCommand is a single entry of a JSON compilation database.
+type compileCommand struct {
+	Directory string   `json:"directory"`
+	File      string   `json:"file"`
+	Command   string   `json:"command"`
+	Arguments []string `json:"arguments"`
+}
+
+// Ensure CProvider implements Provider.
+var _ Provider = (*CProvider)(nil)
+
+func (p *CProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:        path,
+		Language:    "cpp",
+		PackagePath: filepath.ToSlash(absPath),
+		Imports:     []string{},
+		Exports:     []string{},
+		Metadata:    make(map[string]interface{}),
+	}
+
+	filename := filepath.Base(path)
+	ext := filepath.Ext(filename)
+	dna.Package = strings.TrimSuffix(filename, ext)
+
+	parser := sitter.NewParser()
+	// Headers are parsed as C++, which accepts virtually all C declarations too.
+	if ext == ".c" {
+		parser.SetLanguage(c.GetLanguage())
+	} else {
+		parser.SetLanguage(cpp.GetLanguage())
+	}
+	if ext == ".c" || ext == ".h" {
+		dna.Language = "c"
+	}
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	includeDirs := p.includeDirsFor(absPath)
+	walkCTree(tree.RootNode(), content, dna, func(include string, quoted bool) string {
+		return resolveCInclude(filepath.Dir(absPath), include, quoted, includeDirs)
+	})
+
+	return dna, nil
+}
+
+// includeDirsFor returns the include directories used to compile absPath.
+func (p *CProvider) includeDirsFor(absPath string) []string {
+	var dirs []string
+	if db := p.databaseFor(filepath.Dir(absPath)); db != nil {
+		if fileDirs, ok := db.files[absPath]; ok {
+			dirs = append(dirs, fileDirs...)
+		} else {
+			// Headers rarely have their own entry; use every directory the project compiles with.
+			dirs = append(dirs, db.all...)
+		}
+	}
+	for _, dir := range p.IncludeDirs {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dirs = append(dirs, abs)
+		}
+	}
+	return dirs
+}
+
+// databaseFor finds the compile_commands.json governing dir, looking in each
+// ancestor directory and its build/ subdirectory.
+func (p *CProvider) databaseFor(dir string) *compileDatabase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.databases == nil {
+		p.databases = make(map[string]*compileDatabase)
+	}
+
+	var visited []string
+	var db *compileDatabase
+	for current := dir; ; {
+		if cached, ok := p.databases[current]; ok {
+			db = cached
+			break
+		}
+		visited = append(visited, current)
+		if db = loadCompileDatabase(current); db != nil {
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	for _, d := range visited {
+		p.databases[d] = db
+	}
+	return db
+}
+
+func loadCompileDatabase(dir string) *compileDatabase {
+	for _, candidate := range []string{
+		filepath.Join(dir, "compile_commands.json"),
+		filepath.Join(dir, "build", "compile_commands.json"),
+	} {
+		if content, err := os.ReadFile(candidate); err == nil {
+			return parseCompileDatabase(content)
+		}
+	}
+	return nil
+}
+
+func parseCompileDatabase(content []byte) *compileDatabase {
+	var commands []compileCommand
+	if err := json.Unmarshal(content, &commands); err != nil {
+		return nil
+	}
+
+	db := &compileDatabase{files: make(map[string][]string)}
+	seen := make(map[string]bool)
+	for _, cmd := range commands {
+		args := cmd.Arguments
+		if len(args) == 0 {
+			args = splitCommandLine(cmd.Command)
+		}
+
+		flags := []string{"-I", "-isystem", "-iquote", "-idirafter"}
+		if len(args) > 0 && isMSVCCompiler(args[0]) {
+			// Only MSVC-style drivers take /I; elsewhere it is the start of an absolute path.
+			flags = append(flags, "/I")
+		}
+
+		var dirs []string
+		for i := 0; i < len(args); i++ {
+			arg := args[i]
+			var dir string
+			for _, flag := range flags {
+				if arg == flag && i+1 < len(args) {
+					dir = args[i+1]
+					i++
+					break
+				}
+				if strings.HasPrefix(arg, flag) && len(arg) > len(flag) {
+					dir = arg[len(flag):]
+					break
+				}
+			}
+			if dir == "" {
+				continue
+			}
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(cmd.Directory, dir)
+			}
+			dir = filepath.Clean(dir)
+			dirs = append(dirs, dir)
+			if !seen[dir] {
+				seen[dir] = true
+				db.all = append(db.all, dir)
+			}
+		}
+
+		file := cmd.File
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(cmd.Directory, file)
+		}
+		db.files[filepath.Clean(file)] = dirs
+	}
+	return db
+}
+
+// isMSVCCompiler reports whether a compiler executable takes MSVC-style
+// options (cl.exe, clang-cl).
+func isMSVCCompiler(compiler string) bool {
+	name := strings.ToLower(filepath.Base(strings.ReplaceAll(compiler, "\\", "/")))
+	name = strings.TrimSuffix(name, ".exe")
+	return name == "cl" || name == "clang-cl"
+}
+
+// splitCommandLine splits a shell command line, honouring quotes and backslash escapes.
+func splitCommandLine(command string) []string {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg, escaped := false, false
+	for _, r := range command {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+// resolveCInclude maps an include to the absolute path of an existing file.
+// Quoted includes search the including file's directory first, as compilers do.
+// Unresolved includes (usually system headers) are returned unchanged.
+func resolveCInclude(fileDir, include string, quoted bool, includeDirs []string) string {
+	dirs := includeDirs
+	if quoted {
+		dirs = append([]string{fileDir}, includeDirs...)
+	}
+	for _, dir := range dirs {
+		candidate := filepath.Join(dir, include)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return filepath.ToSlash(candidate)
+		}
+	}
+	return include
+}
+
+func walkCTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, resolve func(include string, quoted bool) string) {
+	if node == nil {
+		return
+	}
+
+	switch node.Type() {
+	case "preproc_include":
+		if pathNode := node.ChildByFieldName("path"); pathNode != nil {
+			include := pathNode.Content(sourceCode)
+			quoted := pathNode.Type() == "string_literal"
+			include = strings.Trim(include, "\"<>")
+			dna.Imports = append(dna.Imports, resolve(include, quoted))
+		}
+		return
+	case "function_definition":
+		if name := cDeclaratorName(node.ChildByFieldName("declarator"), sourceCode); name != "" {
+			dna.Exports = append(dna.Exports, name)
+		}
+		// Function bodies only contain local declarations.
+		return
+	case "class_specifier", "struct_specifier", "union_specifier", "enum_specifier":
+		if node.ChildByFieldName("body") != nil {
+			if name := node.ChildByFieldName("name"); name != nil {
+				dna.Exports = append(dna.Exports, name.Content(sourceCode))
+			}
+		}
+		return
+	case "type_definition", "alias_declaration":
+		if name := cDeclaratorName(node.ChildByFieldName("declarator"), sourceCode); name != "" {
+			dna.Exports = append(dna.Exports, name)
+		} else if name := node.ChildByFieldName("name"); name != nil {
+			dna.Exports = append(dna.Exports, name.Content(sourceCode))
+		}
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		walkCTree(node.Child(i), sourceCode, dna, resolve)
+	}
+}
+
+// cDeclaratorName digs through pointer/function/reference declarators to the declared name.
+func cDeclaratorName(node *sitter.Node, sourceCode []byte) string {
+	for node != nil {
+		switch node.Type() {
+		case "identifier", "field_identifier", "type_identifier", "qualified_identifier", "destructor_name", "operator_name":
+			return node.Content(sourceCode)
+		}
+		node = node.ChildByFieldName("declarator")
+	}
+	return ""
+}
+
+// CExtensions lists the file extensions handled by CProvider.
+var CExtensions = []string{".c", ".h", ".cc", ".cpp", ".cxx", ".hpp", ".hh", ".hxx"}
+
+func init() {
+	provider := &CProvider{}
+	for _, ext := range CExtensions {
+		Register(ext, provider)
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseCompileDatabase(t *testing.T) {
+	db := parseCompileDatabase([]byte(`[
+  {"directory": "/src", "file": "main.c", "command": "gcc -Iinclude -isystem /opt/sdk/include -c /Include/main.c"},
+  {"directory": "/src", "file": "win.c", "arguments": ["C:/VC/bin/cl.exe", "/Iwin", "/c", "win.c"]}
+]`))
+	if db == nil {
+		t.Fatal("expected a compilation database")
+	}
+
+	if want := []string{"/src/include", "/opt/sdk/include"}; !slices.Equal(db.files["/src/main.c"], want) {
+		t.Errorf("expected include dirs %v, got %v", want, db.files["/src/main.c"])
+	}
+	if want := []string{"/src/win"}; !slices.Equal(db.files["/src/win.c"], want) {
+		t.Errorf("expected MSVC include dirs %v, got %v", want, db.files["/src/win.c"])
+	}
+}
+
+func TestCProviderResolvesIncludes(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"compile_commands.json": `[{"directory": "` + filepath.ToSlash(root) + `", "file": "src/main.c", "command": "cc -Ilib/include -c src/main.c"}]`,
+		"lib/include/util.h":    "int util(void);\n",
+		"src/local.h":           "#define LOCAL 1\n",
+		"src/main.c":            "#include \"local.h\"\n#include <util.h>\n#include <stdio.h>\nint main(void) { return util(); }\n",
+	})
+
+	dna, err := (&CProvider{}).ParseFile(filepath.Join(root, "src/main.c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.ToSlash(filepath.Join(root, "src/local.h")),
+		filepath.ToSlash(filepath.Join(root, "lib/include/util.h")),
+		"stdio.h",
+	}
+	if !slices.Equal(dna.Imports, want) {
+		t.Errorf("expected imports %v, got %v", want, dna.Imports)
+	}
+}
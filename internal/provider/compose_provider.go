@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// ComposeProvider implements the MultiProvider interface for docker-compose
+// files.
+//
+// Every service and declared network becomes a node. A service imports the
+// services it depends_on, links to or shares volumes with, the networks it
+// joins, and the Dockerfile it is built from. A service built from a
+// subdirectory groups the source files under its build context. Services and
+// networks are keyed by the compose file's directory, so override files of
+// the same project merge.
+type ComposeProvider struct{}
+
+// Ensure ComposeProvider implements MultiProvider.
+var _ MultiProvider = (*ComposeProvider)(nil)
+
+type composeFile struct {
+	Services map[string]composeService  `yaml:"services"`
+	Networks map[string]*composeNetwork `yaml:"networks"`
+}
+
+type composeService struct {
+	Image       string       `yaml:"image"`
+	Build       composeBuild `yaml:"build"`
+	DependsOn   composeKeys  `yaml:"depends_on"`
+	Links       []string     `yaml:"links"`
+	VolumesFrom []string     `yaml:"volumes_from"`
+	NetworkMode string       `yaml:"network_mode"`
+	Networks    composeKeys  `yaml:"networks"`
+}
+
+type composeNetwork struct {
+	Name     string `yaml:"name"`
+	External bool   `yaml:"external"`
+}
+
+// composeBuild accepts both `build: ./dir` and `build: {context: ./dir}`.
+type composeBuild struct {
+	Context    string `yaml:"context"`
+	Dockerfile string `yaml:"dockerfile"`
+}
+
+func (b *composeBuild) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		b.Context = node.Value
+		return nil
+	}
+	type plain composeBuild
+	return node.Decode((*plain)(b))
+}
+
+// composeKeys accepts both the list and the mapping form of depends_on and
+// networks, keeping only the names.
+type composeKeys []string
+
+func (k *composeKeys) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+		*k = names
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			*k = append(*k, node.Content[i].Value)
+		}
+	}
+	return nil
+}
+
+// ParseFile summarises a compose file as a single node exporting its services.
+func (p *ComposeProvider) ParseFile(path string) (*core.FileDNA, error) {
+	nodes, err := p.ParseFileNodes(path)
+	if err != nil {
+		return nil, err
+	}
+	return fileSummary(path, "compose", "compose", nodes), nil
+}
+
+func (p *ComposeProvider) ParseFileNodes(path string) ([]*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file composeFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(absPath)
+	project := filepath.ToSlash(dir)
+
+	networkKey := func(name string) string {
+		if network := file.Networks[name]; network != nil && network.External {
+			// External networks are shared across compose projects by name.
+			if network.Name != "" {
+				name = network.Name
+			}
+			return "network:" + name
+		}
+		return "network:" + project + "/" + name
+	}
+
+	var nodes []*core.FileDNA
+	for _, name := range sortedKeys(file.Networks) {
+		key := networkKey(name)
+		nodes = append(nodes, &core.FileDNA{
+			Path:        path + "/networks/" + name,
+			Language:    "compose",
+			Kind:        "network",
+			Package:     name,
+			PackagePath: key,
+			Provides:    []string{key},
+			Imports:     []string{},
+			Exports:     []string{},
+			Metadata:    make(map[string]interface{}),
+		})
+	}
+
+	for _, name := range sortedKeys(file.Services) {
+		service := file.Services[name]
+		key := "service:" + project + "/" + name
+		dna := &core.FileDNA{
+			Path:        path + "/" + name,
+			Language:    "compose",
+			Kind:        "service",
+			Package:     name,
+			PackagePath: key,
+			Provides:    []string{key},
+			Imports:     []string{},
+			Exports:     []string{},
+			Metadata:    make(map[string]interface{}),
+		}
+
+		seen := make(map[string]bool)
+		addService := func(target string) {
+			if target != "" && target != name {
+				dna.Imports = appendUnique(dna.Imports, seen, "service:"+project+"/"+target)
+			}
+		}
+		for _, dep := range service.DependsOn {
+			addService(dep)
+		}
+		for _, link := range service.Links {
+			// "db:database" links service db under the alias database.
+			addService(strings.SplitN(link, ":", 2)[0])
+		}
+		for _, from := range service.VolumesFrom {
+			if !strings.HasPrefix(from, "container:") {
+				addService(strings.SplitN(from, ":", 2)[0])
+			}
+		}
+		if target, ok := strings.CutPrefix(service.NetworkMode, "service:"); ok {
+			addService(target)
+		}
+		for _, network := range service.Networks {
+			dna.Imports = appendUnique(dna.Imports, seen, networkKey(network))
+		}
+
+		if service.Image != "" {
+			dna.Metadata["image"] = service.Image
+		}
+		if service.Build.Context != "" {
+			dockerfile := service.Build.Dockerfile
+			if dockerfile == "" {
+				dockerfile = "Dockerfile"
+			}
+			buildContext := filepath.Join(dir, service.Build.Context)
+			dna.Imports = appendUnique(dna.Imports, seen, filepath.ToSlash(filepath.Join(buildContext, dockerfile)))
+			dna.Metadata["buildContext"] = filepath.ToSlash(buildContext)
+			// A context of the compose directory itself is the whole project,
+			// which would swallow every other service.
+			if buildContext != dir {
+				dna.GroupDir = filepath.Join(filepath.Dir(path), service.Build.Context)
+			}
+			if service.Image != "" {
+				// The image this service builds can be the base of other Dockerfiles.
+				dna.Provides = append(dna.Provides, "image:"+imageName(service.Image))
+			}
+		} else if service.Image != "" {
+			dna.Imports = appendUnique(dna.Imports, seen, "image:"+imageName(service.Image))
+		}
+
+		nodes = append(nodes, dna)
+	}
+	return nodes, nil
+}
+
+// imageName strips the tag and digest from an image reference, keeping any
+// registry port ("localhost:5000/app:1.0" -> "localhost:5000/app").
+func imageName(image string) string {
+	if idx := strings.Index(image, "@"); idx != -1 {
+		image = image[:idx]
+	}
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		image = image[:idx]
+	}
+	return image
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	for _, pattern := range []string{"docker-compose*.yml", "docker-compose*.yaml", "compose.yml", "compose.yaml", "compose.*.yml", "compose.*.yaml"} {
+		RegisterName(pattern, &ComposeProvider{})
+	}
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+func TestComposeProviderServices(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"compose.yaml": `services:
+  api:
+    build: ./api
+    image: acme/api:1.4
+    depends_on: [db]
+    networks: [backend]
+  worker:
+    build:
+      context: ./worker
+      dockerfile: Dockerfile.worker
+    depends_on:
+      api:
+        condition: service_healthy
+  db:
+    image: postgres:16
+    networks:
+      backend:
+        aliases: [database]
+networks:
+  backend: {}
+`,
+		"api/Dockerfile": `FROM golang:1.22 AS build
+COPY . .
+FROM alpine:3.20
+COPY --from=build /out/api /usr/bin/api
+COPY --from=0 /etc/ssl /etc/ssl
+`,
+		"api/main.go": "package main\n\nfunc main() {}\n",
+		"worker/Dockerfile.worker": `FROM acme/api:1.4
+COPY --from=nginx:1.25 /etc/nginx /etc/nginx
+`,
+	})
+
+	eng := engine.New()
+	for _, name := range []string{"compose.yaml", "api/Dockerfile", "api/main.go", "worker/Dockerfile.worker"} {
+		path := filepath.Join(root, name)
+		p, ok := ForFile(path)
+		if !ok {
+			t.Fatalf("no provider for %s", name)
+		}
+		if multi, ok := p.(MultiProvider); ok {
+			nodes, err := multi.ParseFileNodes(path)
+			if err != nil {
+				t.Fatalf("ParseFileNodes(%s) failed: %v", name, err)
+			}
+			for _, dna := range nodes {
+				eng.IngestFileDNA(dna)
+			}
+			continue
+		}
+		dna, err := p.ParseFile(path)
+		if err != nil {
+			t.Fatalf("ParseFile(%s) failed: %v", name, err)
+		}
+		eng.IngestFileDNA(dna)
+	}
+	eng.LinkDependencies()
+
+	api := eng.FileMap[filepath.Join(root, "api/Dockerfile")]
+	if want := []string{"image:golang", "image:alpine"}; !slices.Equal(api.Imports, want) {
+		t.Errorf("expected base images %v without build stages, got %v", want, api.Imports)
+	}
+
+	for _, edge := range [][2]string{
+		{"compose.yaml/db", "compose.yaml/api"},               // depends_on list
+		{"compose.yaml/api", "compose.yaml/worker"},           // depends_on mapping
+		{"compose.yaml/networks/backend", "compose.yaml/api"}, // network
+		{"compose.yaml/networks/backend", "compose.yaml/db"},  // network mapping
+		{"api/Dockerfile", "compose.yaml/api"},                // build context
+		{"worker/Dockerfile.worker", "compose.yaml/worker"},   // custom dockerfile
+		{"compose.yaml/api", "worker/Dockerfile.worker"},      // FROM an image built by a service
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+
+	worker := eng.FileMap[filepath.Join(root, "worker/Dockerfile.worker")]
+	if !slices.Contains(worker.Imports, "image:nginx") {
+		t.Errorf("expected COPY --from image import, got %v", worker.Imports)
+	}
+
+	groups := map[string]string{}
+	for _, node := range eng.GetGraph().Nodes {
+		groups[node.ID] = node.Group
+	}
+	if got := groups[filepath.Join(root, "api/main.go")]; got != filepath.Join(root, "compose.yaml/api") {
+		t.Errorf("expected the build context source to be grouped under its service, got %q", got)
+	}
+}
@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/csharp"
+)
+
+// CSharpProvider implements the Provider interface for C# files.
+//
+// C# namespaces span many files, so besides the `using` imports the provider
+// records every referenced type name qualified with each namespace in scope.
+// The engine then links the exact files declaring those types instead of
+// collapsing the namespace onto a single file. Every type visible inside its
+// assembly (public, internal or protected) is exported, nested ones as
+// "Outer.Inner"; private types are not. The public subset, which other
+// projects can see, is recorded in Metadata["public"].
+type CSharpProvider struct {
+	mu       sync.Mutex
+	projects map[string]string // directory -> name of the nearest .csproj ("" if none)
+}
+
+// Ensure CSharpProvider implements Provider.
+var _ Provider = (*CSharpProvider)(nil)
+
+func (p *CSharpProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: "csharp",
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+
+	filename := filepath.Base(path)
+	dna.Package = strings.TrimSuffix(filename, filepath.Ext(filename))
+	if project := p.projectFor(filepath.Dir(path)); project != "" {
+		dna.Metadata["project"] = project
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(csharp.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	walker := &csharpWalker{source: content, dna: dna}
+	walker.walk(tree.RootNode(), "", "", true, true)
+	if len(walker.public) > 0 {
+		dna.Metadata["public"] = walker.public
+	}
+
+	// Types resolve against the enclosing namespaces (innermost first) and
+	// every namespace brought in with `using`.
+	var scopes []string
+	for _, ns := range walker.namespaces {
+		for parts := strings.Split(ns, "."); len(parts) > 0; parts = parts[:len(parts)-1] {
+			scopes = append(scopes, strings.Join(parts, "."))
+		}
+	}
+	scopes = append(scopes, walker.usings...)
+	dna.Uses = qualifyTypeRefs(walker.refs, scopes)
+
+	return dna, nil
+}
+
+// projectFor returns the name of the nearest .csproj at or above dir.
+func (p *CSharpProvider) projectFor(dir string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.projects == nil {
+		p.projects = make(map[string]string)
+	}
+
+	var visited []string
+	project := ""
+	for current := dir; ; {
+		if cached, ok := p.projects[current]; ok {
+			project = cached
+			break
+		}
+		visited = append(visited, current)
+		if matches, _ := filepath.Glob(filepath.Join(current, "*.csproj")); len(matches) > 0 {
+			project = strings.TrimSuffix(filepath.Base(matches[0]), ".csproj")
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	for _, d := range visited {
+		p.projects[d] = project
+	}
+	return project
+}
+
+// csharpWalker collects declarations and references from a C# syntax tree.
+type csharpWalker struct {
+	source     []byte
+	dna        *core.FileDNA
+	namespaces []string // declared namespaces, in order
+	usings     []string // namespaces imported with `using`
+	refs       []string // referenced type names
+	public     []string // exported types visible outside the assembly
+	seen       map[string]bool
+}
+
+// walk visits node within a namespace and, for members, the enclosing type.
+// visible is false inside a type that is not itself exported, and public is
+// false inside one other assemblies can't see.
+func (w *csharpWalker) walk(node *sitter.Node, namespace, enclosing string, visible, public bool) {
+	if node == nil {
+		return
+	}
+
+	switch node.Type() {
+	case "using_directive":
+		w.using(node)
+		return
+	case "namespace_declaration", "file_scoped_namespace_declaration":
+		nameNode := node.ChildByFieldName("name")
+		if nameNode == nil {
+			return
+		}
+		name := nameNode.Content(w.source)
+		if namespace != "" && node.Type() == "namespace_declaration" {
+			name = namespace + "." + name
+		}
+		w.namespaces = append(w.namespaces, name)
+		if w.dna.PackagePath == "" {
+			w.dna.PackagePath = name
+		}
+		// A file-scoped namespace applies to the declarations that follow it,
+		// which the compilation_unit loop below takes care of.
+		if node.Type() == "namespace_declaration" {
+			for i := 0; i < int(node.ChildCount()); i++ {
+				if node.FieldNameForChild(i) != "name" {
+					w.walk(node.Child(i), name, "", true, true)
+				}
+			}
+		}
+		return
+	case "class_declaration", "struct_declaration", "interface_declaration", "enum_declaration",
+		"record_declaration", "record_struct_declaration", "delegate_declaration":
+		nameNode := node.ChildByFieldName("name")
+		if nameNode == nil {
+			break
+		}
+		name := nameNode.Content(w.source)
+		if enclosing != "" {
+			name = enclosing + "." + name
+		}
+		// Types default to internal, nested types to private.
+		modifiers := csharpModifiers(node, w.source)
+		visible = visible && !(modifiers["private"] && !modifiers["protected"]) &&
+			(enclosing == "" || modifiers["public"] || modifiers["internal"] || modifiers["protected"])
+		public = public && visible && modifiers["public"]
+		if visible {
+			exported := name
+			// Types outside the file's primary namespace are exported fully qualified.
+			if namespace != w.dna.PackagePath && namespace != "" {
+				exported = namespace + "." + name
+			}
+			w.dna.Exports = append(w.dna.Exports, exported)
+			if public {
+				w.public = append(w.public, exported)
+			}
+		}
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if node.FieldNameForChild(i) != "name" {
+				w.walk(node.Child(i), namespace, name, visible, public)
+			}
+		}
+		return
+	case "identifier":
+		if name := node.Content(w.source); isCapitalized(name) {
+			if w.seen == nil {
+				w.seen = make(map[string]bool)
+			}
+			w.refs = appendUnique(w.refs, w.seen, name)
+		}
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		if node.Type() == "compilation_unit" && child.Type() == "file_scoped_namespace_declaration" {
+			w.walk(child, namespace, enclosing, visible, public)
+			if nameNode := child.ChildByFieldName("name"); nameNode != nil {
+				namespace = nameNode.Content(w.source)
+			}
+			continue
+		}
+		w.walk(child, namespace, enclosing, visible, public)
+	}
+}
+
+// using records `using Ns;` and `using static Ns.Type;` directives. Aliases
+// (`using A = Ns.Type;`) import the aliased type directly.
+func (w *csharpWalker) using(node *sitter.Node) {
+	var target string
+	isStatic, isAlias := false, node.ChildByFieldName("name") != nil
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		switch child.Type() {
+		case "static":
+			isStatic = true
+		case "qualified_name", "identifier":
+			if node.FieldNameForChild(i) != "name" {
+				target = child.Content(w.source)
+			}
+		}
+	}
+	if target == "" {
+		return
+	}
+
+	w.dna.Imports = append(w.dna.Imports, target)
+	if !isStatic && !isAlias {
+		w.usings = append(w.usings, target)
+	}
+}
+
+// csharpModifiers returns the modifiers of a declaration.
+func csharpModifiers(node *sitter.Node, source []byte) map[string]bool {
+	modifiers := make(map[string]bool)
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if child := node.Child(i); child.Type() == "modifier" {
+			modifiers[child.Content(source)] = true
+		}
+	}
+	return modifiers
+}
+
+// CSProjProvider implements the Provider interface for .csproj files, turning
+// each project into a node with edges to the projects it references. The
+// project groups the source files under its directory.
+type CSProjProvider struct{}
+
+// Ensure CSProjProvider implements Provider.
+var _ Provider = (*CSProjProvider)(nil)
+
+type csproj struct {
+	ItemGroups []struct {
+		ProjectReferences []struct {
+			Include string `xml:"Include,attr"`
+		} `xml:"ProjectReference"`
+	} `xml:"ItemGroup"`
+}
+
+func (p *CSProjProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var project csproj
+	if err := xml.Unmarshal(content, &project); err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:        path,
+		Package:     strings.TrimSuffix(filepath.Base(path), ".csproj"),
+		PackagePath: filepath.ToSlash(absPath),
+		Language:    "msbuild",
+		Kind:        "project",
+		GroupDir:    filepath.Dir(path),
+		Imports:     []string{},
+		Exports:     []string{},
+		Metadata:    make(map[string]interface{}),
+	}
+
+	for _, group := range project.ItemGroups {
+		for _, ref := range group.ProjectReferences {
+			// MSBuild paths use backslashes regardless of platform.
+			include := filepath.FromSlash(strings.ReplaceAll(ref.Include, "\\", "/"))
+			target := filepath.Join(filepath.Dir(absPath), include)
+			dna.Imports = append(dna.Imports, filepath.ToSlash(target))
+		}
+	}
+
+	return dna, nil
+}
+
+func init() {
+	Register(".cs", &CSharpProvider{})
+	Register(".csproj", &CSProjProvider{})
+}
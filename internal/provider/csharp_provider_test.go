@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+func TestCSharpProviderExportsAndProjects(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"Core/Core.csproj": "<Project Sdk=\"Microsoft.NET.Sdk\"></Project>\n",
+		"Core/Money.cs": `namespace Acme.Core;
+
+public class Money
+{
+    public struct Currency {}
+    private class Cache {}
+}
+
+class Rounding {}
+`,
+		"Core/Ledger.cs": `namespace Acme.Core;
+
+public class Ledger { Rounding rounding; }
+`,
+		"Billing/Billing.csproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <ItemGroup>
+    <ProjectReference Include="..\Core\Core.csproj" />
+  </ItemGroup>
+</Project>
+`,
+		"Billing/Invoice.cs": `using Acme.Core;
+
+namespace Acme.Billing
+{
+    public class Invoice { Money total; }
+}
+`,
+	})
+
+	cs, csproj := &CSharpProvider{}, &CSProjProvider{}
+	eng := engine.New()
+	for _, name := range []string{"Core/Core.csproj", "Core/Money.cs", "Core/Ledger.cs", "Billing/Billing.csproj", "Billing/Invoice.cs"} {
+		var p Provider = cs
+		if filepath.Ext(name) == ".csproj" {
+			p = csproj
+		}
+		dna, err := p.ParseFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("ParseFile(%s) failed: %v", name, err)
+		}
+		eng.IngestFileDNA(dna)
+	}
+	eng.LinkDependencies()
+
+	money := eng.FileMap[filepath.Join(root, "Core/Money.cs")]
+	if want := []string{"Money", "Money.Currency", "Rounding"}; !slices.Equal(money.Exports, want) {
+		t.Errorf("expected non-private types %v, got %v", want, money.Exports)
+	}
+	if want := []string{"Money", "Money.Currency"}; !slices.Equal(money.Metadata["public"].([]string), want) {
+		t.Errorf("expected public types %v, got %v", want, money.Metadata["public"])
+	}
+
+	for _, edge := range [][2]string{
+		{"Core/Money.cs", "Billing/Invoice.cs"},
+		{"Core/Money.cs", "Core/Ledger.cs"}, // internal type in the same namespace
+		{"Core/Core.csproj", "Billing/Billing.csproj"},
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+
+	groups := map[string]string{}
+	for _, node := range eng.GetGraph().Nodes {
+		groups[node.ID] = node.Group
+	}
+	if got := groups[filepath.Join(root, "Billing/Invoice.cs")]; got != filepath.Join(root, "Billing/Billing.csproj") {
+		t.Errorf("expected Invoice.cs to be grouped under its project, got %q", got)
+	}
+}
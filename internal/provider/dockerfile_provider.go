@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/dockerfile"
+)
+
+// DockerfileProvider implements the Provider interface for Dockerfiles.
+//
+// A Dockerfile is keyed by its absolute path, which is what compose services
+// import for their build context. FROM and COPY --from images that are not
+// earlier build stages are imported as "image:<name>", linking them to compose
+// services that build that image.
+type DockerfileProvider struct{}
+
+// Ensure DockerfileProvider implements Provider.
+var _ Provider = (*DockerfileProvider)(nil)
+
+func (p *DockerfileProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:        path,
+		Language:    "dockerfile",
+		Package:     filepath.Base(filepath.Dir(absPath)),
+		PackagePath: filepath.ToSlash(absPath),
+		Imports:     []string{},
+		Exports:     []string{},
+		Metadata:    make(map[string]interface{}),
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(dockerfile.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	stages := make(map[string]bool)
+	seen := make(map[string]bool)
+	addImage := func(image string) {
+		// Images from build args can't be resolved statically.
+		if !stages[image] && !strings.Contains(image, "$") && image != "scratch" {
+			dna.Imports = appendUnique(dna.Imports, seen, "image:"+image)
+		}
+	}
+
+	root := tree.RootNode()
+	for i := 0; i < int(root.ChildCount()); i++ {
+		instruction := root.Child(i)
+		switch instruction.Type() {
+		case "from_instruction":
+			if spec := childOfType(instruction, "image_spec"); spec != nil {
+				if name := spec.ChildByFieldName("name"); name != nil {
+					addImage(name.Content(content))
+				}
+			}
+			if alias := instruction.ChildByFieldName("as"); alias != nil {
+				stages[alias.Content(content)] = true
+			}
+		case "copy_instruction":
+			// COPY --from takes a stage name, a stage index or an image.
+			for j := 0; j < int(instruction.ChildCount()); j++ {
+				param := instruction.Child(j)
+				if param.Type() != "param" {
+					continue
+				}
+				from, ok := strings.CutPrefix(param.Content(content), "--from=")
+				if _, err := strconv.Atoi(from); ok && err != nil {
+					addImage(imageName(from))
+				}
+			}
+		}
+	}
+	if len(stages) > 0 {
+		dna.Metadata["stages"] = sortedKeys(stages)
+	}
+
+	return dna, nil
+}
+
+func init() {
+	for _, pattern := range []string{"Dockerfile", "Dockerfile.*", "*.Dockerfile", "*.dockerfile"} {
+		RegisterName(pattern, &DockerfileProvider{})
+	}
+}
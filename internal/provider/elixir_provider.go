@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/elixir"
+)
+
+// ElixirProvider implements the Provider interface for Elixir files.
+//
+// Modules are global in Elixir, so symbols are keyed by their full module name
+// ("MyApp.Repo") and public functions by "Module.function". Files inside an
+// umbrella project's apps/<name> directory get the app name as their package;
+// the apps themselves are module nodes built from mix.exs (see MixProvider).
+type ElixirProvider struct{}
+
+// Ensure ElixirProvider implements Provider.
+var _ Provider = (*ElixirProvider)(nil)
+
+func (p *ElixirProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: "elixir",
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+
+	filename := filepath.Base(path)
+	dna.Package = strings.TrimSuffix(filename, filepath.Ext(filename))
+	if app := umbrellaApp(path); app != "" {
+		dna.Package = app
+		dna.Metadata["umbrellaApp"] = app
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(elixir.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	walker := &elixirWalker{source: content, dna: dna, defined: make(map[string]bool), used: make(map[string]bool)}
+	walker.walk(tree.RootNode(), "", map[string]string{})
+
+	return dna, nil
+}
+
+// umbrellaApp returns the app name if path lives in apps/<name> of an umbrella
+// project, i.e. both the app and the project root have a mix.exs.
+func umbrellaApp(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	for dir := filepath.Dir(absPath); ; {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		if filepath.Base(parent) == "apps" {
+			_, appErr := os.Stat(filepath.Join(dir, "mix.exs"))
+			_, rootErr := os.Stat(filepath.Join(filepath.Dir(parent), "mix.exs"))
+			if appErr == nil && rootErr == nil {
+				return filepath.Base(dir)
+			}
+		}
+		dir = parent
+	}
+}
+
+// elixirWalker collects modules, directives and remote calls.
+type elixirWalker struct {
+	source  []byte
+	dna     *core.FileDNA
+	defined map[string]bool
+	used    map[string]bool
+}
+
+// expandAlias resolves the first segment of a module name through the aliases in scope.
+func expandAlias(name string, aliases map[string]string) string {
+	first, rest, hasRest := strings.Cut(name, ".")
+	if full, ok := aliases[first]; ok {
+		if hasRest {
+			return full + "." + rest
+		}
+		return full
+	}
+	return name
+}
+
+func (w *elixirWalker) addUse(use string) {
+	if !w.used[use] {
+		w.used[use] = true
+		w.dna.Uses = append(w.dna.Uses, use)
+	}
+}
+
+func (w *elixirWalker) walk(node *sitter.Node, module string, aliases map[string]string) {
+	if node == nil {
+		return
+	}
+
+	if node.Type() == "call" {
+		target := node.ChildByFieldName("target")
+		args := elixirArguments(node)
+		if target != nil && target.Type() == "identifier" {
+			switch target.Content(w.source) {
+			case "defmodule":
+				if name := elixirFirstAlias(args); name != nil {
+					full := expandAlias(name.Content(w.source), aliases)
+					if module != "" {
+						full = module + "." + name.Content(w.source)
+					}
+					w.dna.Exports = append(w.dna.Exports, full)
+					if w.dna.PackagePath == "" {
+						w.dna.PackagePath = full
+					}
+					// Aliases are lexically scoped to the module body.
+					scoped := make(map[string]string, len(aliases))
+					for k, v := range aliases {
+						scoped[k] = v
+					}
+					for i := 0; i < int(node.ChildCount()); i++ {
+						if child := node.Child(i); child.Type() == "do_block" {
+							w.walk(child, full, scoped)
+						}
+					}
+					return
+				}
+			case "alias":
+				w.alias(args, aliases)
+				return
+			case "import", "use", "require":
+				if name := elixirFirstAlias(args); name != nil {
+					w.dna.Imports = append(w.dna.Imports, expandAlias(name.Content(w.source), aliases))
+				}
+				return
+			case "def", "defmacro", "defdelegate", "defguard":
+				if args != nil && module != "" {
+					if head := args.Child(0); head != nil {
+						name := head
+						if head.Type() == "call" {
+							name = head.ChildByFieldName("target")
+						} else if head.Type() == "binary_operator" {
+							// `def name(args) when guard`
+							if left := head.ChildByFieldName("left"); left != nil && left.Type() == "call" {
+								name = left.ChildByFieldName("target")
+							}
+						}
+						if name != nil && name.Type() == "identifier" {
+							export := module + "." + name.Content(w.source)
+							if !w.defined[export] {
+								w.defined[export] = true
+								w.dna.Exports = append(w.dna.Exports, export)
+							}
+						}
+					}
+				}
+			}
+		} else if target != nil && target.Type() == "dot" {
+			// Remote call such as `Repo.insert(...)`: record the module and the function.
+			left, right := target.ChildByFieldName("left"), target.ChildByFieldName("right")
+			if left != nil && left.Type() == "alias" {
+				remote := expandAlias(left.Content(w.source), aliases)
+				w.addUse(remote)
+				if right != nil && right.Type() == "identifier" {
+					w.addUse(remote + "." + right.Content(w.source))
+				}
+			}
+		}
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		w.walk(node.Child(i), module, aliases)
+	}
+}
+
+// alias handles `alias A.B`, `alias A.B, as: C` and `alias A.{B, C.D}`.
+func (w *elixirWalker) alias(args *sitter.Node, aliases map[string]string) {
+	if args == nil || args.ChildCount() == 0 {
+		return
+	}
+	first := args.Child(0)
+
+	switch first.Type() {
+	case "alias":
+		full := expandAlias(first.Content(w.source), aliases)
+		short := full[strings.LastIndex(full, ".")+1:]
+		if as := elixirKeyword(args, "as", w.source); as != "" {
+			short = as
+		}
+		aliases[short] = full
+		w.dna.Imports = append(w.dna.Imports, full)
+	case "dot":
+		left, right := first.ChildByFieldName("left"), first.ChildByFieldName("right")
+		if left == nil || right == nil || right.Type() != "tuple" {
+			return
+		}
+		prefix := expandAlias(left.Content(w.source), aliases)
+		for i := 0; i < int(right.ChildCount()); i++ {
+			if item := right.Child(i); item.Type() == "alias" {
+				full := prefix + "." + item.Content(w.source)
+				aliases[full[strings.LastIndex(full, ".")+1:]] = full
+				w.dna.Imports = append(w.dna.Imports, full)
+			}
+		}
+	}
+}
+
+func elixirArguments(call *sitter.Node) *sitter.Node {
+	for i := 0; i < int(call.ChildCount()); i++ {
+		if child := call.Child(i); child.Type() == "arguments" {
+			return child
+		}
+	}
+	return nil
+}
+
+func elixirFirstAlias(args *sitter.Node) *sitter.Node {
+	if args == nil {
+		return nil
+	}
+	for i := 0; i < int(args.ChildCount()); i++ {
+		if child := args.Child(i); child.Type() == "alias" {
+			return child
+		}
+	}
+	return nil
+}
+
+// elixirKeyword returns the alias value of a keyword argument such as `as: L`.
+func elixirKeyword(args *sitter.Node, key string, source []byte) string {
+	for i := 0; i < int(args.ChildCount()); i++ {
+		keywords := args.Child(i)
+		if keywords.Type() != "keywords" {
+			continue
+		}
+		for j := 0; j < int(keywords.ChildCount()); j++ {
+			pair := keywords.Child(j)
+			k, v := pair.ChildByFieldName("key"), pair.ChildByFieldName("value")
+			if k != nil && v != nil && strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(k.Content(source)), ":")) == key {
+				return v.Content(source)
+			}
+		}
+	}
+	return ""
+}
+
+func init() {
+	provider := &ElixirProvider{}
+	Register(".ex", provider)
+	Register(".exs", provider)
+}
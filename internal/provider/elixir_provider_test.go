@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+func TestElixirProviderUmbrella(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"mix.exs":           "defmodule Shop.Umbrella.MixProject do\n  use Mix.Project\n  def project, do: [apps_path: \"apps\", deps: []]\nend\n",
+		"apps/core/mix.exs": "defmodule Core.MixProject do\n  use Mix.Project\n  def project, do: [app: :core, deps: []]\nend\n",
+		"apps/web/mix.exs": `defmodule Web.MixProject do
+  use Mix.Project
+  def project, do: [app: :web, deps: deps()]
+  defp deps do
+    [{:core, in_umbrella: true}, {:phoenix, "~> 1.7"}]
+  end
+end
+`,
+		"apps/core/lib/core/repo.ex": "defmodule Core.Repo do\n  def insert(record), do: record\nend\n",
+		"apps/web/lib/web/order_controller.ex": `defmodule Web.OrderController do
+  alias Core.Repo
+
+  def create(params), do: Repo.insert(params)
+end
+`,
+	})
+
+	eng := engine.New()
+	for _, name := range []string{"mix.exs", "apps/core/mix.exs", "apps/web/mix.exs", "apps/core/lib/core/repo.ex", "apps/web/lib/web/order_controller.ex"} {
+		path := filepath.Join(root, name)
+		p, ok := ForFile(path)
+		if !ok {
+			t.Fatalf("no provider for %s", name)
+		}
+		dna, err := p.ParseFile(path)
+		if err != nil {
+			t.Fatalf("ParseFile(%s) failed: %v", name, err)
+		}
+		eng.IngestFileDNA(dna)
+	}
+	eng.LinkDependencies()
+
+	for _, edge := range [][2]string{
+		{"apps/core/lib/core/repo.ex", "apps/web/lib/web/order_controller.ex"}, // remote call through an alias
+		{"apps/core/mix.exs", "apps/web/mix.exs"},                              // in_umbrella dependency
+		{"apps/web/mix.exs", "mix.exs"},                                        // umbrella app
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+
+	controller := eng.FileMap[filepath.Join(root, "apps/web/lib/web/order_controller.ex")]
+	if controller.Package != "web" {
+		t.Errorf("expected umbrella app package web, got %q", controller.Package)
+	}
+	groups := map[string]string{}
+	for _, node := range eng.GetGraph().Nodes {
+		groups[node.ID] = node.Group
+	}
+	if got := groups[controller.Path]; got != filepath.Join(root, "apps/web/mix.exs") {
+		t.Errorf("expected the controller to be grouped under its app, got %q", got)
+	}
+}
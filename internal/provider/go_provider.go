@@ -15,8 +15,15 @@ import (
 
 // GoProvider implements the Provider interface for Go files.
 type GoProvider struct {
+	// TypeChecked resolves Uses with go/types instead of syntactic guesses.
+	// It loads every package of the enclosing module, so it is slower and
+	// needs a working go toolchain; files that fail to load fall back to the
+	// syntactic parser.
+	TypeChecked bool
+
 	mu          sync.RWMutex
-	moduleCache map[string]string // directory -> module name
+	moduleCache map[string]string       // directory -> module name
+	typedCache  map[string]*typedModule // module root -> type-checked packages
 }
 
 // Ensure GoProvider implements Provider.
@@ -29,6 +36,12 @@ func (p *GoProvider) ParseFile(path string) (*core.FileDNA, error) {
 		return nil, nil
 	}
 
+	if p.TypeChecked {
+		if dna, ok := p.parseTyped(path); ok {
+			return dna, nil
+		}
+	}
+
 	fset := token.NewFileSet()
 	// Parse the file with comments to get flexible parsing, though we don't strictly need comments yet.
 	node, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
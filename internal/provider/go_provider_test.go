@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoProviderTypeChecked(t *testing.T) {
+	p := &GoProvider{TypeChecked: true}
+
+	path, _ := filepath.Abs("../engine/engine.go")
+	dna, err := p.ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if dna.Metadata["typeChecked"] != true {
+		t.Skip("module could not be type-checked in this environment")
+	}
+
+	uses := make(map[string]bool)
+	for _, use := range dna.Uses {
+		uses[use] = true
+	}
+
+	for _, want := range []string{
+		"github.com/ritiksrivastava/archhelix/internal/graph.Graph",
+		"github.com/ritiksrivastava/archhelix/internal/core.FileDNA",
+		"sync.RWMutex.Lock",
+	} {
+		if !uses[want] {
+			t.Errorf("expected use %q, got %v", want, dna.Uses)
+		}
+	}
+
+	for use := range uses {
+		if strings.HasSuffix(use, ".string") || strings.HasSuffix(use, ".len") || strings.HasSuffix(use, ".nil") {
+			t.Errorf("builtin leaked into uses: %q", use)
+		}
+	}
+
+	exports := strings.Join(dna.Exports, ",")
+	if !strings.Contains(exports, "Engine.LinkDependencies") {
+		t.Errorf("expected method exports to be receiver-qualified, got %v", dna.Exports)
+	}
+}
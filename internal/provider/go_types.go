@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+)
+
+// typedModule holds the type-checked packages of a single Go module.
+type typedModule struct {
+	once  sync.Once
+	files map[string]typedFile // absolute file path -> file
+}
+
+type typedFile struct {
+	pkg  *packages.Package
+	file *ast.File
+}
+
+// loadTypedModule type-checks every package of the module rooted at moduleRoot.
+// The result is cached so each module is only loaded once per provider.
+func (p *GoProvider) loadTypedModule(moduleRoot string) *typedModule {
+	p.mu.Lock()
+	if p.typedCache == nil {
+		p.typedCache = make(map[string]*typedModule)
+	}
+	mod, ok := p.typedCache[moduleRoot]
+	if !ok {
+		mod = &typedModule{}
+		p.typedCache[moduleRoot] = mod
+	}
+	p.mu.Unlock()
+
+	mod.once.Do(func() {
+		mod.files = make(map[string]typedFile)
+		cfg := &packages.Config{
+			Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+				packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedTypesInfo,
+			Dir: moduleRoot,
+		}
+		pkgs, err := packages.Load(cfg, "./...")
+		if err != nil {
+			return
+		}
+		for _, pkg := range pkgs {
+			if pkg.TypesInfo == nil {
+				continue
+			}
+			for _, file := range pkg.Syntax {
+				name := pkg.Fset.File(file.Pos()).Name()
+				mod.files[name] = typedFile{pkg: pkg, file: file}
+			}
+		}
+	})
+	return mod
+}
+
+// parseTyped extracts a file's DNA from go/types information. It reports false
+// when the file could not be type-checked, so callers can fall back to the
+// syntactic parser.
+func (p *GoProvider) parseTyped(path string) (*core.FileDNA, bool) {
+	_, moduleRoot := p.getModuleInfo(path)
+	if moduleRoot == "" {
+		return nil, false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, false
+	}
+	absRoot, err := filepath.Abs(moduleRoot)
+	if err != nil {
+		return nil, false
+	}
+
+	tf, ok := p.loadTypedModule(absRoot).files[absPath]
+	if !ok {
+		return nil, false
+	}
+	pkg, info := tf.pkg, tf.pkg.TypesInfo
+
+	dna := &core.FileDNA{
+		Path:        path,
+		Package:     pkg.Name,
+		PackagePath: pkg.PkgPath,
+		Language:    "go",
+		Imports:     []string{},
+		Exports:     []string{},
+		Metadata:    map[string]interface{}{"typeChecked": true},
+	}
+
+	for _, imp := range tf.file.Imports {
+		if imp.Path != nil {
+			dna.Imports = append(dna.Imports, unquote(imp.Path.Value))
+		}
+	}
+
+	// Exports: methods are qualified by their receiver type so that methods
+	// sharing a name across types map to distinct symbols.
+	for _, decl := range tf.file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if obj, ok := info.Defs[d.Name].(*types.Func); ok {
+				dna.Exports = append(dna.Exports, localSymbol(obj))
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					dna.Exports = append(dna.Exports, s.Name.Name)
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						if name.Name != "_" {
+							dna.Exports = append(dna.Exports, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Uses: every identifier resolved to a package-level object or method.
+	seen := make(map[string]bool)
+	ast.Inspect(tf.file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		obj := info.Uses[ident]
+		if obj == nil || obj.Pkg() == nil {
+			// Builtins such as string, nil and len live in the universe scope.
+			return true
+		}
+		symbol := qualifiedSymbol(obj)
+		if symbol != "" && !seen[symbol] {
+			seen[symbol] = true
+			dna.Uses = append(dna.Uses, symbol)
+		}
+		return true
+	})
+
+	return dna, true
+}
+
+// qualifiedSymbol returns the fully qualified name of obj (e.g. "pkg.Type.Method"),
+// or "" for objects that cannot be referenced from another file.
+func qualifiedSymbol(obj types.Object) string {
+	switch o := obj.(type) {
+	case *types.PkgName, *types.Label:
+		return ""
+	case *types.Func:
+		o = o.Origin()
+		if o.Type().(*types.Signature).Recv() != nil {
+			if local := localSymbol(o); local != o.Name() {
+				return o.Pkg().Path() + "." + local
+			}
+			return ""
+		}
+	case *types.Var:
+		if o.IsField() {
+			return ""
+		}
+	}
+	if obj.Parent() != obj.Pkg().Scope() {
+		// Local variables, parameters and the like.
+		return ""
+	}
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// localSymbol returns the package-relative name of a function, using the
+// "Type.Method" form for methods.
+func localSymbol(fn *types.Func) string {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return fn.Name()
+	}
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj().Name() + "." + fn.Name()
+	}
+	return fn.Name()
+}
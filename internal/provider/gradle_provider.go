@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+)
+
+// GradleProvider implements the Provider interface for Gradle build scripts,
+// in both the Groovy and the Kotlin DSL.
+//
+// Each build.gradle(.kts) is a module node keyed by its project path within
+// the build ("gradle:<root>:core"), taken from the include() and projectDir
+// declarations of the nearest settings.gradle(.kts). `project(":x")`
+// references become imports, and the module groups every file under its
+// directory. The settings file itself imports every included project.
+type GradleProvider struct {
+	mu       sync.Mutex
+	settings map[string]*gradleSettings // directory -> settings governing it (nil if none)
+}
+
+// gradleSettings is the project layout declared by a settings script.
+type gradleSettings struct {
+	root     string            // directory of the settings script
+	name     string            // rootProject.name
+	projects map[string]string // project directory -> project path
+}
+
+// Ensure GradleProvider implements Provider.
+var _ Provider = (*GradleProvider)(nil)
+
+var (
+	gradleInclude     = regexp.MustCompile(`\binclude\b\s*(?:\(([^)]*)\)|([^\n(]+))`)
+	gradleQuoted      = regexp.MustCompile(`["']([^"']+)["']`)
+	gradleProjectDir  = regexp.MustCompile(`project\(\s*["']([^"']+)["']\s*\)\.projectDir\s*=\s*(?:file|new\s+File)\s*\(\s*["']([^"']+)["']`)
+	gradleRootName    = regexp.MustCompile(`rootProject\.name\s*=\s*["']([^"']+)["']`)
+	gradleProjectCall = regexp.MustCompile(`\bproject\(\s*(?:path\s*[:=]\s*)?["'](:[^"']*)["']`)
+)
+
+var gradleSettingsFiles = []string{"settings.gradle", "settings.gradle.kts"}
+
+func (p *GradleProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	absDir := filepath.Dir(absPath)
+
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: "gradle",
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+
+	settings := p.findSettings(absDir)
+	if settings == nil {
+		settings = &gradleSettings{root: absDir, projects: map[string]string{}}
+	}
+	prefix := "gradle:" + filepath.ToSlash(settings.root)
+
+	seen := make(map[string]bool)
+	if strings.HasPrefix(filepath.Base(path), "settings.gradle") {
+		dna.Kind = "settings"
+		dna.Package = settings.name
+		dna.PackagePath = prefix
+		for _, projectPath := range sortedValues(settings.projects) {
+			dna.Imports = appendUnique(dna.Imports, seen, prefix+projectPath)
+		}
+		return dna, nil
+	}
+
+	projectPath := gradleProjectPath(settings, absDir)
+	dna.Kind = "module"
+	dna.Package = projectPath[strings.LastIndex(projectPath, ":")+1:]
+	if projectPath == ":" {
+		dna.Package = settings.name
+	}
+	dna.PackagePath = prefix + projectPath
+	dna.GroupDir = filepath.Dir(path)
+	dna.Metadata["project"] = projectPath
+
+	for _, m := range gradleProjectCall.FindAllStringSubmatch(string(content), -1) {
+		dna.Imports = appendUnique(dna.Imports, seen, prefix+m[1])
+	}
+
+	return dna, nil
+}
+
+// gradleProjectPath returns the project path of a build script directory:
+// its declared projectDir, or the default mapping of path segments to
+// subdirectories of the root.
+func gradleProjectPath(settings *gradleSettings, dir string) string {
+	if projectPath, ok := settings.projects[dir]; ok {
+		return projectPath
+	}
+	rel, err := filepath.Rel(settings.root, dir)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return ":"
+	}
+	return ":" + strings.ReplaceAll(filepath.ToSlash(rel), "/", ":")
+}
+
+// findSettings returns the settings script governing dir, read once per
+// directory and cached.
+func (p *GradleProvider) findSettings(dir string) *gradleSettings {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.settings == nil {
+		p.settings = make(map[string]*gradleSettings)
+	}
+
+	var visited []string
+	var found *gradleSettings
+	for current := dir; ; {
+		if cached, ok := p.settings[current]; ok {
+			found = cached
+			break
+		}
+		visited = append(visited, current)
+		if settings := readGradleSettings(current); settings != nil {
+			found = settings
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	for _, d := range visited {
+		p.settings[d] = found
+	}
+	return found
+}
+
+func readGradleSettings(dir string) *gradleSettings {
+	for _, name := range gradleSettingsFiles {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		text := string(content)
+		settings := &gradleSettings{root: dir, name: filepath.Base(dir), projects: make(map[string]string)}
+		if m := gradleRootName.FindStringSubmatch(text); m != nil {
+			settings.name = m[1]
+		}
+
+		projectDirs := make(map[string]string)
+		for _, m := range gradleProjectDir.FindAllStringSubmatch(text, -1) {
+			projectDirs[gradleNormalizePath(m[1])] = filepath.Join(dir, filepath.FromSlash(m[2]))
+		}
+		for _, m := range gradleInclude.FindAllStringSubmatch(text, -1) {
+			for _, q := range gradleQuoted.FindAllStringSubmatch(m[1]+m[2], -1) {
+				projectPath := gradleNormalizePath(q[1])
+				projectDir, ok := projectDirs[projectPath]
+				if !ok {
+					projectDir = filepath.Join(dir, filepath.FromSlash(strings.ReplaceAll(strings.TrimPrefix(projectPath, ":"), ":", "/")))
+				}
+				settings.projects[projectDir] = projectPath
+			}
+		}
+		return settings
+	}
+	return nil
+}
+
+// gradleNormalizePath makes include("core") and include(":core") equal.
+func gradleNormalizePath(projectPath string) string {
+	if !strings.HasPrefix(projectPath, ":") {
+		return ":" + projectPath
+	}
+	return projectPath
+}
+
+func sortedValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, key := range sortedKeys(m) {
+		values = append(values, m[key])
+	}
+	return values
+}
+
+func init() {
+	p := &GradleProvider{}
+	for _, name := range []string{"build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"} {
+		RegisterName(name, p)
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGradleProviderProjects(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"settings.gradle.kts": `rootProject.name = "shop"
+include(":core", "services:billing")
+include ":web"
+project(":web").projectDir = file("frontend/web")
+`,
+		"build.gradle.kts":                  "plugins { java }\n",
+		"core/build.gradle":                 "apply plugin: 'java'\n",
+		"services/billing/build.gradle.kts": "dependencies {\n    implementation(project(\":core\"))\n}\n",
+		"frontend/web/build.gradle": `dependencies {
+    implementation project(path: ':services:billing')
+    implementation project(':core')
+}
+`,
+	})
+
+	files := []string{"settings.gradle.kts", "build.gradle.kts", "core/build.gradle", "services/billing/build.gradle.kts", "frontend/web/build.gradle"}
+	eng := ingestAll(t, &GradleProvider{}, root, files...)
+
+	prefix := "gradle:" + filepath.ToSlash(root)
+	want := map[string]string{
+		"settings.gradle.kts":               prefix,
+		"build.gradle.kts":                  prefix + ":",
+		"core/build.gradle":                 prefix + ":core",
+		"services/billing/build.gradle.kts": prefix + ":services:billing",
+		"frontend/web/build.gradle":         prefix + ":web",
+	}
+	for name, packagePath := range want {
+		if got := eng.FileMap[filepath.Join(root, name)].PackagePath; got != packagePath {
+			t.Errorf("%s: expected project %q, got %q", name, packagePath, got)
+		}
+	}
+	if got := eng.FileMap[filepath.Join(root, "build.gradle.kts")].Package; got != "shop" {
+		t.Errorf("expected the root project to be named shop, got %q", got)
+	}
+
+	for _, edge := range [][2]string{
+		{"core/build.gradle", "services/billing/build.gradle.kts"},         // project(":core")
+		{"services/billing/build.gradle.kts", "frontend/web/build.gradle"}, // project(path: ...)
+		{"core/build.gradle", "frontend/web/build.gradle"},
+		{"frontend/web/build.gradle", "settings.gradle.kts"}, // include with projectDir
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+}
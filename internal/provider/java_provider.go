@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/java"
+)
+
+// JavaProvider implements the Provider interface for Java files.
+type JavaProvider struct{}
+
+// Ensure JavaProvider implements Provider.
+var _ Provider = (*JavaProvider)(nil)
+
+func (p *JavaProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: "java",
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(java.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	// Java files are named after their primary type, so use that as the "package" name.
+	filename := filepath.Base(path)
+	dna.Package = strings.TrimSuffix(filename, filepath.Ext(filename))
+
+	root := tree.RootNode()
+	var wildcards []string
+	for i := 0; i < int(root.ChildCount()); i++ {
+		child := root.Child(i)
+		switch child.Type() {
+		case "package_declaration":
+			for j := 0; j < int(child.ChildCount()); j++ {
+				if name := child.Child(j); name.Type() == "scoped_identifier" || name.Type() == "identifier" {
+					dna.PackagePath = name.Content(content)
+				}
+			}
+		case "import_declaration":
+			if imp, wildcard := javaImport(child, content); imp != "" {
+				if wildcard {
+					// On-demand imports name a whole package; individual types are
+					// resolved through Uses below instead of linking the package.
+					wildcards = append(wildcards, imp)
+					dna.Imports = append(dna.Imports, imp+".*")
+				} else {
+					dna.Imports = append(dna.Imports, imp)
+				}
+			}
+		}
+	}
+
+	walkJavaTree(root, content, dna, "")
+
+	// Types from the same package and from on-demand imports need no explicit
+	// import, so qualify every referenced type name with each candidate package.
+	scopes := append([]string{dna.PackagePath}, wildcards...)
+	dna.Uses = qualifyTypeRefs(collectJavaTypeRefs(root, content), scopes)
+
+	return dna, nil
+}
+
+// javaImport returns the importable name of an import declaration and whether
+// it is an on-demand (wildcard) package import. Static imports resolve to the
+// declaring class, since members are not tracked as separate symbols.
+func javaImport(node *sitter.Node, content []byte) (string, bool) {
+	var name string
+	isStatic, isWildcard := false, false
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		switch child.Type() {
+		case "static":
+			isStatic = true
+		case "asterisk":
+			isWildcard = true
+		case "scoped_identifier", "identifier":
+			name = child.Content(content)
+		}
+	}
+
+	if isStatic {
+		if isWildcard {
+			return name, false
+		}
+		if lastDot := strings.LastIndex(name, "."); lastDot != -1 {
+			return name[:lastDot], false
+		}
+	}
+	return name, isWildcard
+}
+
+// walkJavaTree records type declarations as exports. Nested types are
+// exported with their enclosing type prefix (e.g. "Outer.Inner").
+func walkJavaTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, enclosing string) {
+	if node == nil {
+		return
+	}
+
+	switch node.Type() {
+	case "class_declaration", "interface_declaration", "enum_declaration", "record_declaration", "annotation_type_declaration":
+		if nameNode := node.ChildByFieldName("name"); nameNode != nil {
+			name := nameNode.Content(sourceCode)
+			if enclosing != "" {
+				name = enclosing + "." + name
+			}
+			dna.Exports = append(dna.Exports, name)
+			enclosing = name
+		}
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		walkJavaTree(node.Child(i), sourceCode, dna, enclosing)
+	}
+}
+
+// collectJavaTypeRefs returns the simple type names referenced in the tree,
+// including receivers of static calls such as `Factory.create()`.
+func collectJavaTypeRefs(node *sitter.Node, sourceCode []byte) []string {
+	var refs []string
+	seen := make(map[string]bool)
+
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		switch n.Type() {
+		case "import_declaration", "package_declaration":
+			return
+		case "type_identifier":
+			refs = appendUnique(refs, seen, n.Content(sourceCode))
+		case "method_invocation", "field_access":
+			if obj := n.ChildByFieldName("object"); obj != nil && obj.Type() == "identifier" {
+				if name := obj.Content(sourceCode); isCapitalized(name) {
+					refs = appendUnique(refs, seen, name)
+				}
+			}
+		}
+		for i := 0; i < int(n.ChildCount()); i++ {
+			walk(n.Child(i))
+		}
+	}
+	walk(node)
+
+	return refs
+}
+
+// qualifyTypeRefs qualifies each simple name with every scope, skipping empty scopes.
+func qualifyTypeRefs(refs []string, scopes []string) []string {
+	var uses []string
+	for _, scope := range scopes {
+		if scope == "" {
+			continue
+		}
+		for _, ref := range refs {
+			uses = append(uses, scope+"."+ref)
+		}
+	}
+	return uses
+}
+
+func appendUnique(list []string, seen map[string]bool, value string) []string {
+	if seen[value] {
+		return list
+	}
+	seen[value] = true
+	return append(list, value)
+}
+
+func isCapitalized(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+func init() {
+	Register(".java", &JavaProvider{})
+}
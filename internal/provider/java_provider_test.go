@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestJavaProviderResolvesImports(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"core/Money.java":      "package com.acme.core;\npublic final class Money {}\n",
+		"util/Strings.java":    "package com.acme.util;\npublic class Strings { public static String join() { return \"\"; } }\n",
+		"util/Factory.java":    "package com.acme.util;\npublic class Factory { public static class Builder {} }\n",
+		"billing/Invoice.java": "package com.acme.billing;\npublic record Invoice(int id) {}\n",
+		"billing/InvoiceService.java": `package com.acme.billing;
+
+import com.acme.core.Money;
+import com.acme.util.*;
+import static com.acme.util.Strings.join;
+
+public class InvoiceService {
+    private Money total;
+    public Invoice build() { return Factory.create(); }
+}
+`,
+	})
+
+	p := &JavaProvider{}
+	eng := engine.New()
+	for _, name := range []string{"core/Money.java", "util/Strings.java", "util/Factory.java", "billing/Invoice.java", "billing/InvoiceService.java"} {
+		dna, err := p.ParseFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("ParseFile(%s) failed: %v", name, err)
+		}
+		eng.IngestFileDNA(dna)
+	}
+	eng.LinkDependencies()
+
+	service := eng.FileMap[filepath.Join(root, "billing/InvoiceService.java")]
+	if service.PackagePath != "com.acme.billing" {
+		t.Errorf("expected package com.acme.billing, got %q", service.PackagePath)
+	}
+
+	for _, dep := range []string{"core/Money.java", "util/Strings.java", "util/Factory.java", "billing/Invoice.java"} {
+		if !hasPathEdge(eng, root, dep, "billing/InvoiceService.java") {
+			t.Errorf("expected edge %s -> billing/InvoiceService.java", dep)
+		}
+	}
+
+	factory := eng.FileMap[filepath.Join(root, "util/Factory.java")]
+	if len(factory.Exports) != 2 || factory.Exports[1] != "Factory.Builder" {
+		t.Errorf("expected nested type export, got %v", factory.Exports)
+	}
+}
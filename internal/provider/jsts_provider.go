@@ -1,20 +1,37 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/ritiksrivastava/archhelix/internal/core"
 	sitter "github.com/smacker/go-tree-sitter"
 	"github.com/smacker/go-tree-sitter/javascript"
 	"github.com/smacker/go-tree-sitter/typescript/tsx"
 	"github.com/smacker/go-tree-sitter/typescript/typescript"
+	"gopkg.in/yaml.v3"
 )
 
 // JSTSProvider implements the Provider interface for JS and TS files.
-type JSTSProvider struct{}
+//
+// Relative imports resolve against the importing file. Bare specifiers go
+// through the `paths` and `baseUrl` of the nearest tsconfig.json or
+// jsconfig.json (following its `extends` chain), then the packages of the
+// enclosing npm/yarn/pnpm workspace, and are kept as external packages if
+// they don't map to a file.
+type JSTSProvider struct {
+	mu         sync.Mutex
+	configs    map[string]*tsconfig      // directory -> nearest tsconfig (nil if none)
+	workspaces map[string]*jstsWorkspace // directory -> enclosing workspace (nil if none)
+}
 
 // Ensure JSTSProvider implements Provider.
 var _ Provider = (*JSTSProvider)(nil)
@@ -61,7 +78,19 @@ func (p *JSTSProvider) ParseFile(path string) (*core.FileDNA, error) {
 		dna.Package = filename
 	}
 
-	// Determine logical PackagePath
+	var basePackage string
+	dna.PackagePath, basePackage = jstsModulePath(path)
+
+	walkJSTSTree(tree.RootNode(), content, dna, p.resolver(path, basePackage))
+
+	return dna, nil
+}
+
+// jstsModulePath returns the logical module path of a file (e.g. "src.utils.api"
+// for src/utils/api.ts, or "src.utils" for src/utils/index.ts) and the base
+// package relative imports are resolved against.
+func jstsModulePath(path string) (string, string) {
+	ext := filepath.Ext(path)
 	normalizedPath := filepath.ToSlash(path)
 	normalizedPath = strings.TrimSuffix(normalizedPath, ext)
 	if strings.HasSuffix(normalizedPath, "/index") {
@@ -69,15 +98,10 @@ func (p *JSTSProvider) ParseFile(path string) (*core.FileDNA, error) {
 	} else if normalizedPath == "index" {
 		normalizedPath = ""
 	}
-	dna.PackagePath = strings.ReplaceAll(normalizedPath, "/", ".")
-
-	basePackage := dna.PackagePath
-	isIndex := false
-	if strings.HasPrefix(filename, "index.") {
-		isIndex = true
-	}
+	packagePath := strings.ReplaceAll(normalizedPath, "/", ".")
 
-	if !isIndex {
+	basePackage := packagePath
+	if !strings.HasPrefix(filepath.Base(path), "index.") {
 		lastDot := strings.LastIndex(basePackage, ".")
 		if lastDot != -1 {
 			basePackage = basePackage[:lastDot]
@@ -85,10 +109,7 @@ func (p *JSTSProvider) ParseFile(path string) (*core.FileDNA, error) {
 			basePackage = ""
 		}
 	}
-
-	walkJSTSTree(tree.RootNode(), content, dna, basePackage)
-
-	return dna, nil
+	return packagePath, basePackage
 }
 
 func unquote(s string) string {
@@ -99,7 +120,7 @@ func unquote(s string) string {
 }
 
 func resolveJSTSImport(basePackage string, relImport string) string {
-	for _, ext := range []string{".js", ".ts", ".jsx", ".tsx", ".cjs", ".mjs", ".mts", ".cts"} {
+	for _, ext := range []string{".js", ".ts", ".jsx", ".tsx", ".cjs", ".mjs", ".mts", ".cts", ".vue", ".svelte"} {
 		if strings.HasSuffix(relImport, ext) {
 			relImport = strings.TrimSuffix(relImport, ext)
 			break
@@ -150,18 +171,529 @@ func resolveJSTSImport(basePackage string, relImport string) string {
 	return resolvedBase + "." + relImport
 }
 
-func walkJSTSTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, basePackage string) {
+// resolver returns the function turning the import specifiers of a file into
+// module paths.
+func (p *JSTSProvider) resolver(path, basePackage string) func(string) string {
+	return func(spec string) string {
+		if strings.HasPrefix(spec, ".") {
+			return resolveJSTSImport(basePackage, spec)
+		}
+		if absPath, err := filepath.Abs(path); err == nil {
+			dir := filepath.Dir(absPath)
+			file, ok := "", false
+			if config := p.findConfig(dir); config != nil {
+				file, ok = config.resolve(spec)
+			}
+			if workspace := p.findWorkspace(dir); !ok && workspace != nil {
+				file, ok = workspace.resolve(spec)
+			}
+			if ok {
+				modulePath, _ := jstsModulePath(sameForm(path, file))
+				return modulePath
+			}
+		}
+		return resolveJSTSImport(basePackage, spec)
+	}
+}
+
+// sameForm returns an absolute path in the form of ref, the path of the
+// importing file as handed to the provider: absolute, or relative to the same
+// scan root. A relative form is derived from ref's own directory, so module
+// paths don't depend on the process working directory.
+func sameForm(ref, abs string) string {
+	if filepath.IsAbs(ref) {
+		return abs
+	}
+	absRef, err := filepath.Abs(ref)
+	if err != nil {
+		return abs
+	}
+	rel, err := filepath.Rel(filepath.Dir(absRef), abs)
+	if err != nil {
+		return abs
+	}
+	return filepath.Join(filepath.Dir(ref), rel)
+}
+
+// tsconfig holds the module resolution options of a tsconfig.json or
+// jsconfig.json, with its extends chain applied.
+type tsconfig struct {
+	baseURL  string              // absolute; "" if unset
+	paths    map[string][]string // alias pattern -> substitutions
+	pathsDir string              // directory of the config declaring paths
+}
+
+// tsconfigFile is the subset of tsconfig.json we read.
+type tsconfigFile struct {
+	Extends         json.RawMessage `json:"extends"`
+	CompilerOptions struct {
+		BaseURL *string             `json:"baseUrl"`
+		Paths   map[string][]string `json:"paths"`
+	} `json:"compilerOptions"`
+}
+
+var jstsExtensions = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs", ".mts", ".cts", ".vue", ".svelte"}
+
+// resolve maps a bare specifier to a file through paths, then baseUrl. It
+// reports false if no file matches.
+func (c *tsconfig) resolve(spec string) (string, bool) {
+	base := c.pathsDir
+	if c.baseURL != "" {
+		base = c.baseURL
+	}
+
+	// An exact pattern wins, then the wildcard pattern with the longest prefix.
+	best, bestPrefix, captured := "", -1, ""
+	for pattern := range c.paths {
+		prefix, suffix, wildcard := strings.Cut(pattern, "*")
+		switch {
+		case !wildcard && pattern == spec:
+			best, bestPrefix, captured = pattern, len(spec)+1, ""
+		case wildcard && len(prefix) > bestPrefix && len(spec) >= len(prefix)+len(suffix) &&
+			strings.HasPrefix(spec, prefix) && strings.HasSuffix(spec, suffix):
+			best, bestPrefix, captured = pattern, len(prefix), spec[len(prefix):len(spec)-len(suffix)]
+		}
+	}
+	if bestPrefix >= 0 {
+		for _, substitution := range c.paths[best] {
+			candidate := filepath.Join(base, filepath.FromSlash(strings.Replace(substitution, "*", captured, 1)))
+			if file, ok := jstsFile(candidate); ok {
+				return file, true
+			}
+		}
+	}
+
+	if c.baseURL != "" {
+		return jstsFile(filepath.Join(c.baseURL, filepath.FromSlash(spec)))
+	}
+	return "", false
+}
+
+// jstsFile finds the file an extensionless module path refers to, trying the
+// usual extensions and index files.
+func jstsFile(candidate string) (string, bool) {
+	if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+		return candidate, true
+	}
+	for _, base := range []string{candidate, filepath.Join(candidate, "index")} {
+		for _, ext := range jstsExtensions {
+			if info, err := os.Stat(base + ext); err == nil && !info.IsDir() {
+				return base + ext, true
+			}
+		}
+	}
+	return "", false
+}
+
+// findConfig returns the tsconfig governing dir, read once per directory
+// and cached.
+func (p *JSTSProvider) findConfig(dir string) *tsconfig {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.configs == nil {
+		p.configs = make(map[string]*tsconfig)
+	}
+
+	var visited []string
+	var found *tsconfig
+	for current := dir; ; {
+		if cached, ok := p.configs[current]; ok {
+			found = cached
+			break
+		}
+		visited = append(visited, current)
+		if config := readTSConfigDir(current); config != nil {
+			found = config
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	for _, d := range visited {
+		p.configs[d] = found
+	}
+	return found
+}
+
+func readTSConfigDir(dir string) *tsconfig {
+	for _, name := range []string{"tsconfig.json", "jsconfig.json"} {
+		config := &tsconfig{}
+		if readTSConfig(filepath.Join(dir, name), config, make(map[string]bool)) {
+			return config
+		}
+	}
+	return nil
+}
+
+// readTSConfig applies a config file and the configs it extends onto
+// config. It reports false if the file can't be read.
+func readTSConfig(path string, config *tsconfig, seen map[string]bool) bool {
+	if seen[path] {
+		return false
+	}
+	seen[path] = true
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var file tsconfigFile
+	if err := json.Unmarshal(stripJSONComments(content), &file); err != nil {
+		return false
+	}
+	dir := filepath.Dir(path)
+
+	// "extends" is a string, or an array applied in order since TypeScript 5.0.
+	var extends []string
+	var single string
+	if json.Unmarshal(file.Extends, &single) == nil {
+		extends = []string{single}
+	} else {
+		json.Unmarshal(file.Extends, &extends)
+	}
+	for _, parent := range extends {
+		if parentPath, ok := tsconfigExtendsPath(dir, parent); ok {
+			readTSConfig(parentPath, config, seen)
+		}
+	}
+
+	if file.CompilerOptions.BaseURL != nil {
+		config.baseURL = filepath.Join(dir, filepath.FromSlash(*file.CompilerOptions.BaseURL))
+	}
+	if file.CompilerOptions.Paths != nil {
+		config.paths = file.CompilerOptions.Paths
+		config.pathsDir = dir
+	}
+	return true
+}
+
+// tsconfigExtendsPath locates an extended config: a path relative to the
+// extending config, or a config shipped in a package under node_modules.
+func tsconfigExtendsPath(dir, extends string) (string, bool) {
+	var candidates []string
+	if strings.HasPrefix(extends, ".") || filepath.IsAbs(extends) {
+		candidates = append(candidates, filepath.Join(dir, filepath.FromSlash(extends)))
+	} else {
+		for current := dir; ; current = filepath.Dir(current) {
+			candidates = append(candidates, filepath.Join(current, "node_modules", filepath.FromSlash(extends)))
+			if filepath.Dir(current) == current {
+				break
+			}
+		}
+	}
+	for _, candidate := range candidates {
+		for _, file := range []string{candidate, candidate + ".json", filepath.Join(candidate, "tsconfig.json")} {
+			if info, err := os.Stat(file); err == nil && !info.IsDir() {
+				return file, true
+			}
+		}
+	}
+	return "", false
+}
+
+// jstsWorkspace is an npm/yarn/pnpm workspace: the packages of a monorepo.
+type jstsWorkspace struct {
+	packages []*jstsPackage
+}
+
+type jstsPackage struct {
+	dir      string
+	manifest packageJSON
+}
+
+// packageJSON is the subset of package.json we read.
+type packageJSON struct {
+	Name       string          `json:"name"`
+	Main       string          `json:"main"`
+	Module     string          `json:"module"`
+	Source     string          `json:"source"`
+	Exports    json.RawMessage `json:"exports"`
+	Workspaces json.RawMessage `json:"workspaces"`
+}
+
+// jstsConditions are the export conditions tried, in order. Source and types
+// entries come first since they usually point into the repository rather
+// than at build output.
+var jstsConditions = []string{"source", "types", "import", "module", "default", "require", "node", "browser"}
+
+// resolve maps a specifier naming a workspace package ("@acme/ui" or
+// "@acme/ui/button") to a file through the package's exports or main entry.
+func (w *jstsWorkspace) resolve(spec string) (string, bool) {
+	for _, pkg := range w.packages {
+		subpath, ok := strings.CutPrefix(spec, pkg.manifest.Name)
+		if !ok || (subpath != "" && !strings.HasPrefix(subpath, "/")) {
+			continue
+		}
+		subpath = "." + subpath
+
+		var targets []string
+		if len(pkg.manifest.Exports) > 0 {
+			var exports interface{}
+			if json.Unmarshal(pkg.manifest.Exports, &exports) == nil {
+				targets = jstsExportTargets(exports, subpath)
+			}
+		} else if subpath == "." {
+			targets = []string{pkg.manifest.Source, pkg.manifest.Module, pkg.manifest.Main}
+		} else {
+			targets = []string{subpath}
+		}
+		if subpath == "." {
+			// Entry points often name build output; fall back to the sources.
+			targets = append(targets, "src/index", "index")
+		}
+
+		for _, target := range targets {
+			if target == "" {
+				continue
+			}
+			if file, ok := jstsFile(filepath.Join(pkg.dir, filepath.FromSlash(target))); ok {
+				return file, true
+			}
+			// "./src/index.js" may name the .ts file it is compiled from.
+			if ext := filepath.Ext(target); slices.Contains(jstsExtensions, ext) {
+				if file, ok := jstsFile(filepath.Join(pkg.dir, filepath.FromSlash(strings.TrimSuffix(target, ext)))); ok {
+					return file, true
+				}
+			}
+		}
+		return "", false
+	}
+	return "", false
+}
+
+// jstsExportTargets returns the targets of a subpath in a package.json
+// "exports" value, in order of preference.
+func jstsExportTargets(exports interface{}, subpath string) []string {
+	if m, ok := exports.(map[string]interface{}); ok {
+		isSubpathMap := false
+		for key := range m {
+			isSubpathMap = isSubpathMap || strings.HasPrefix(key, ".")
+		}
+		if isSubpathMap {
+			if value, ok := m[subpath]; ok {
+				return jstsConditionTargets(value, "")
+			}
+			// "./*": "./src/*.ts" - the wildcard pattern with the longest prefix wins.
+			best, bestPrefix, captured := "", -1, ""
+			for key := range m {
+				prefix, suffix, wildcard := strings.Cut(key, "*")
+				if wildcard && len(prefix) > bestPrefix && len(subpath) >= len(prefix)+len(suffix) &&
+					strings.HasPrefix(subpath, prefix) && strings.HasSuffix(subpath, suffix) {
+					best, bestPrefix, captured = key, len(prefix), subpath[len(prefix):len(subpath)-len(suffix)]
+				}
+			}
+			if bestPrefix >= 0 {
+				return jstsConditionTargets(m[best], captured)
+			}
+			return nil
+		}
+	}
+	// A bare target or conditions object is the "." export.
+	if subpath != "." {
+		return nil
+	}
+	return jstsConditionTargets(exports, "")
+}
+
+// jstsConditionTargets flattens an export target, which may be a string, a
+// conditions object or an array of fallbacks, substituting any wildcard.
+func jstsConditionTargets(value interface{}, captured string) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{strings.ReplaceAll(v, "*", captured)}
+	case []interface{}:
+		var targets []string
+		for _, item := range v {
+			targets = append(targets, jstsConditionTargets(item, captured)...)
+		}
+		return targets
+	case map[string]interface{}:
+		var targets []string
+		for _, condition := range jstsConditions {
+			if item, ok := v[condition]; ok {
+				targets = append(targets, jstsConditionTargets(item, captured)...)
+			}
+		}
+		return targets
+	}
+	return nil
+}
+
+// findWorkspace returns the workspace enclosing dir, read once per directory
+// and cached.
+func (p *JSTSProvider) findWorkspace(dir string) *jstsWorkspace {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.workspaces == nil {
+		p.workspaces = make(map[string]*jstsWorkspace)
+	}
+
+	var visited []string
+	var found *jstsWorkspace
+	for current := dir; ; {
+		if cached, ok := p.workspaces[current]; ok {
+			found = cached
+			break
+		}
+		visited = append(visited, current)
+		if workspace := readJSTSWorkspace(current); workspace != nil {
+			found = workspace
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	for _, d := range visited {
+		p.workspaces[d] = found
+	}
+	return found
+}
+
+// readJSTSWorkspace reads the workspace rooted at dir, declared by the
+// "workspaces" of its package.json or by a pnpm-workspace.yaml. It returns
+// nil if dir is not a workspace root.
+func readJSTSWorkspace(dir string) *jstsWorkspace {
+	var patterns []string
+	if content, err := os.ReadFile(filepath.Join(dir, "pnpm-workspace.yaml")); err == nil {
+		var pnpm struct {
+			Packages []string `yaml:"packages"`
+		}
+		if yaml.Unmarshal(content, &pnpm) == nil {
+			patterns = pnpm.Packages
+		}
+	} else if content, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var manifest packageJSON
+		if json.Unmarshal(content, &manifest) != nil || len(manifest.Workspaces) == 0 {
+			return nil
+		}
+		// "workspaces": [...] or, in yarn, "workspaces": {"packages": [...]}
+		var nested struct {
+			Packages []string `json:"packages"`
+		}
+		if json.Unmarshal(manifest.Workspaces, &patterns) != nil && json.Unmarshal(manifest.Workspaces, &nested) == nil {
+			patterns = nested.Packages
+		}
+	} else {
+		return nil
+	}
+
+	var include, exclude []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(strings.TrimSuffix(pattern, "/"), "./")
+		if negated, ok := strings.CutPrefix(pattern, "!"); ok {
+			exclude = append(exclude, strings.TrimPrefix(negated, "./"))
+		} else {
+			include = append(include, pattern)
+		}
+	}
+	includeRe, excludeRe := globPatterns(include), globPatterns(exclude)
+	matches := func(patterns []*regexp.Regexp, rel string) bool {
+		for _, pattern := range patterns {
+			if pattern.MatchString(rel) {
+				return true
+			}
+		}
+		return false
+	}
+
+	workspace := &jstsWorkspace{}
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if path != dir && (d.Name() == "node_modules" || strings.HasPrefix(d.Name(), ".")) {
+			return fs.SkipDir
+		}
+		rel, _ := filepath.Rel(dir, path)
+		rel = filepath.ToSlash(rel)
+		if !matches(includeRe, rel) || matches(excludeRe, rel) {
+			return nil
+		}
+		content, err := os.ReadFile(filepath.Join(path, "package.json"))
+		if err != nil {
+			return nil
+		}
+		var manifest packageJSON
+		if json.Unmarshal(content, &manifest) == nil && manifest.Name != "" {
+			workspace.packages = append(workspace.packages, &jstsPackage{dir: path, manifest: manifest})
+		}
+		return nil
+	})
+	return workspace
+}
+
+var jsonTrailingComma = regexp.MustCompile(`,(\s*[}\]])`)
+
+// stripJSONComments turns JSONC (JSON with comments and trailing commas, as
+// in tsconfig.json) into plain JSON.
+func stripJSONComments(content []byte) []byte {
+	var out []byte
+	inString := false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(content) {
+				i++
+				out = append(out, content[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			out = append(out, '\n')
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := bytes.Index(content[i+2:], []byte("*/"))
+			if end == -1 {
+				return out
+			}
+			i += end + 3
+		default:
+			out = append(out, c)
+		}
+	}
+	return jsonTrailingComma.ReplaceAll(out, []byte("$1"))
+}
+
+// protoSourceComment matches the header protoc plugins (ts-proto, protobuf-es,
+// grpc-web) write into generated files, naming the .proto they came from.
+var protoSourceComment = regexp.MustCompile(`(?:@generated from file|source:)\s+(\S+\.proto)\b`)
+
+// walkJSTSTree collects imports and exports, turning import specifiers into
+// module paths with resolve.
+func walkJSTSTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, resolve func(string) string) {
 	if node == nil {
 		return
 	}
 
 	switch node.Type() {
+	case "comment":
+		// Generated protobuf code depends on the .proto it was generated from.
+		if m := protoSourceComment.FindStringSubmatch(node.Content(sourceCode)); m != nil {
+			dna.Imports = append(dna.Imports, m[1])
+		}
 	case "import_statement":
 		for i := 0; i < int(node.ChildCount()); i++ {
 			child := node.Child(i)
 			if child.Type() == "string" {
 				val := unquote(child.Content(sourceCode))
-				dna.Imports = append(dna.Imports, resolveJSTSImport(basePackage, val))
+				dna.Imports = append(dna.Imports, resolve(val))
 				break
 			}
 		}
@@ -178,7 +710,7 @@ func walkJSTSTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, baseP
 						arg := argsNode.Child(j)
 						if arg.Type() == "string" {
 							val := unquote(arg.Content(sourceCode))
-							dna.Imports = append(dna.Imports, resolveJSTSImport(basePackage, val))
+							dna.Imports = append(dna.Imports, resolve(val))
 							break
 						}
 					}
@@ -233,7 +765,7 @@ func walkJSTSTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, baseP
 			}
 		}
 		if hasFrom && fromString != "" {
-			dna.Imports = append(dna.Imports, resolveJSTSImport(basePackage, fromString))
+			dna.Imports = append(dna.Imports, resolve(fromString))
 		}
 	case "assignment_expression":
 		left := node.ChildByFieldName("left")
@@ -275,12 +807,16 @@ func walkJSTSTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, baseP
 	}
 
 	for i := 0; i < int(node.ChildCount()); i++ {
-		walkJSTSTree(node.Child(i), sourceCode, dna, basePackage)
+		walkJSTSTree(node.Child(i), sourceCode, dna, resolve)
 	}
 }
 
+// jstsProvider is shared by the script extensions and SFCProvider, so both
+// read each tsconfig and workspace manifest once.
+var jstsProvider = &JSTSProvider{}
+
 func init() {
-	provider := &JSTSProvider{}
+	provider := jstsProvider
 	Register(".js", provider)
 	Register(".jsx", provider)
 	Register(".cjs", provider)
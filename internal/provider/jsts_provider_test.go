@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestJSTSProviderPathAliases(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"tsconfig.base.json": `{
+  // Shared by every app.
+  "compilerOptions": {
+    "baseUrl": "./web/src",
+    "paths": { "~app/*": ["store/*"], },
+  },
+}`,
+		"web/tsconfig.json":             `{ "extends": "../tsconfig.base", "compilerOptions": { "strict": true } }`,
+		"web/src/components/Button.tsx": "export const Button = () => null\n",
+		"web/src/store/index.ts":        "export const store = {}\n",
+		"web/src/utils/format.ts":       "export const format = () => ''\n",
+		"web/src/pages/Home.tsx": `import { Button } from "components/Button";
+import { store } from "~app/index";
+import { format } from "../utils/format";
+import React from "react";
+`,
+	})
+
+	dna, err := (&JSTSProvider{}).ParseFile(filepath.Join(root, "web/src/pages/Home.tsx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modulePath := func(name string) string {
+		path, _ := jstsModulePath(filepath.Join(root, name))
+		return path
+	}
+	want := []string{
+		modulePath("web/src/components/Button.tsx"),
+		modulePath("web/src/store/index.ts"),
+		modulePath("web/src/utils/format.ts"),
+		"react",
+	}
+	if !slices.Equal(dna.Imports, want) {
+		t.Errorf("expected imports %v, got %v", want, dna.Imports)
+	}
+}
+
+func TestJSTSProviderAliasesKeepRelativePaths(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app/tsconfig.json":          `{"compilerOptions": {"baseUrl": "src"}}`,
+		"app/src/lib/api.ts":         "export const api = {}\n",
+		"app/src/features/orders.ts": "import { api } from \"lib/api\";\n",
+	})
+
+	// Scanning a relative path keeps module paths relative to the scan root,
+	// so alias targets must come out in the same form as the files themselves.
+	t.Chdir(filepath.Dir(root))
+	scan := filepath.Base(root)
+	dna, err := (&JSTSProvider{}).ParseFile(filepath.Join(scan, "app/src/features/orders.ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, _ := jstsModulePath(filepath.Join(scan, "app/src/lib/api.ts"))
+	if !slices.Equal(dna.Imports, []string{want}) {
+		t.Errorf("expected imports [%s], got %v", want, dna.Imports)
+	}
+}
+
+func TestJSTSProviderWorkspacePackages(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"pnpm-workspace.yaml":          "packages:\n  - 'packages/*'\n  - 'apps/**'\n",
+		"packages/ui/package.json":     `{"name": "@acme/ui", "exports": {".": {"types": "./dist/index.d.ts", "import": "./src/index.js"}, "./*": "./src/*.tsx"}}`,
+		"packages/ui/src/index.ts":     "export const theme = {}\n",
+		"packages/ui/src/Button.tsx":   "export const Button = () => null\n",
+		"packages/ui-kit/package.json": `{"name": "@acme/ui-kit", "main": "dist/index.js"}`,
+		"packages/ui-kit/src/index.ts": "export const kit = {}\n",
+		"apps/admin/web/package.json":  `{"name": "admin"}`,
+		"apps/admin/web/src/App.tsx": `import { theme } from "@acme/ui";
+import { Button } from "@acme/ui/Button";
+import { kit } from "@acme/ui-kit";
+import { z } from "zod";
+`,
+	})
+
+	dna, err := (&JSTSProvider{}).ParseFile(filepath.Join(root, "apps/admin/web/src/App.tsx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modulePath := func(name string) string {
+		path, _ := jstsModulePath(filepath.Join(root, name))
+		return path
+	}
+	want := []string{
+		modulePath("packages/ui/src/index.ts"),
+		modulePath("packages/ui/src/Button.tsx"),
+		modulePath("packages/ui-kit/src/index.ts"),
+		"zod",
+	}
+	if !slices.Equal(dna.Imports, want) {
+		t.Errorf("expected imports %v, got %v", want, dna.Imports)
+	}
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/kotlin"
+)
+
+// KotlinProvider implements the Provider interface for Kotlin files.
+//
+// Kotlin files often don't live in a directory matching their package, so all
+// resolution keys on the declared package. Symbols use the same "pkg.Name" form
+// as the Java provider, which lets Java and Kotlin files import each other.
+type KotlinProvider struct{}
+
+// Ensure KotlinProvider implements Provider.
+var _ Provider = (*KotlinProvider)(nil)
+
+func (p *KotlinProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: "kotlin",
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+
+	filename := filepath.Base(path)
+	dna.Package = strings.TrimSuffix(strings.TrimSuffix(filename, ".kts"), ".kt")
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(kotlin.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	root := tree.RootNode()
+	var wildcards []string
+	for i := 0; i < int(root.ChildCount()); i++ {
+		child := root.Child(i)
+		switch child.Type() {
+		case "package_header":
+			if name := childOfType(child, "identifier"); name != nil {
+				dna.PackagePath = name.Content(content)
+			}
+		case "import_list":
+			for j := 0; j < int(child.ChildCount()); j++ {
+				header := child.Child(j)
+				name := childOfType(header, "identifier")
+				if header.Type() != "import_header" || name == nil {
+					continue
+				}
+				if childOfType(header, "wildcard_import") != nil {
+					wildcards = append(wildcards, name.Content(content))
+					dna.Imports = append(dna.Imports, name.Content(content)+".*")
+				} else {
+					dna.Imports = append(dna.Imports, name.Content(content))
+				}
+			}
+		}
+	}
+
+	walker := &kotlinWalker{source: content, dna: dna, seen: make(map[string]bool)}
+	walker.walk(root, "")
+
+	// Top-level functions and properties compile into a <File>Kt class, which
+	// is what Java code imports to call them.
+	if walker.hasTopLevelMembers {
+		dna.Exports = append(dna.Exports, dna.Package+"Kt")
+	}
+	if len(walker.extensions) > 0 {
+		dna.Metadata["extensions"] = walker.extensions
+	}
+
+	scopes := append([]string{dna.PackagePath}, wildcards...)
+	dna.Uses = append(walker.qualified, qualifyTypeRefs(walker.refs, scopes)...)
+
+	return dna, nil
+}
+
+// childOfType returns the first direct child of the given type. Grammars
+// without field names (Kotlin, SQL) are navigated by node type.
+func childOfType(node *sitter.Node, nodeType string) *sitter.Node {
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if child := node.Child(i); child.Type() == nodeType {
+			return child
+		}
+	}
+	return nil
+}
+
+// kotlinWalker collects declarations and references from a Kotlin syntax tree.
+type kotlinWalker struct {
+	source             []byte
+	dna                *core.FileDNA
+	refs               []string
+	qualified          []string // fully-qualified type references such as a.b.Type
+	extensions         []string // extension functions as "Receiver.name"
+	hasTopLevelMembers bool
+	seen               map[string]bool
+}
+
+func (w *kotlinWalker) walk(node *sitter.Node, enclosing string) {
+	if node == nil {
+		return
+	}
+
+	switch node.Type() {
+	case "package_header", "import_list":
+		return
+	case "class_declaration", "object_declaration":
+		if name := childOfType(node, "type_identifier"); name != nil {
+			qualified := name.Content(w.source)
+			if enclosing != "" {
+				qualified = enclosing + "." + qualified
+			}
+			w.dna.Exports = append(w.dna.Exports, qualified)
+			enclosing = qualified
+		}
+	case "type_alias":
+		if name := childOfType(node, "type_identifier"); name != nil && enclosing == "" {
+			w.dna.Exports = append(w.dna.Exports, name.Content(w.source))
+		}
+	case "function_declaration":
+		if name := childOfType(node, "simple_identifier"); name != nil && enclosing == "" && node.Parent().Type() == "source_file" {
+			w.dna.Exports = append(w.dna.Exports, name.Content(w.source))
+			w.hasTopLevelMembers = true
+			// `fun Receiver.name()` declares an extension function.
+			if receiver := childOfType(node, "user_type"); receiver != nil && receiver.EndByte() < name.StartByte() {
+				w.extensions = append(w.extensions, receiver.Content(w.source)+"."+name.Content(w.source))
+			}
+		}
+	case "property_declaration":
+		if node.Parent().Type() == "source_file" {
+			if decl := childOfType(node, "variable_declaration"); decl != nil {
+				if name := childOfType(decl, "simple_identifier"); name != nil {
+					w.dna.Exports = append(w.dna.Exports, name.Content(w.source))
+					w.hasTopLevelMembers = true
+				}
+			}
+		}
+	case "user_type":
+		// `com.acme.core.Money` is already fully qualified.
+		if strings.Contains(node.Content(w.source), ".") && childOfType(node, "type_arguments") == nil {
+			w.qualified = appendUnique(w.qualified, w.seen, node.Content(w.source))
+			return
+		}
+	case "type_identifier":
+		w.refs = appendUnique(w.refs, w.seen, node.Content(w.source))
+	case "call_expression":
+		// Called names may be constructors, top-level or extension functions
+		// declared in the same package or a wildcard-imported one.
+		if callee := node.Child(0); callee != nil {
+			switch callee.Type() {
+			case "simple_identifier":
+				w.refs = appendUnique(w.refs, w.seen, callee.Content(w.source))
+			case "navigation_expression":
+				if suffix := childOfType(callee, "navigation_suffix"); suffix != nil {
+					if name := childOfType(suffix, "simple_identifier"); name != nil {
+						w.refs = appendUnique(w.refs, w.seen, name.Content(w.source))
+					}
+				}
+			}
+		}
+	case "navigation_expression":
+		// Receivers such as `Factory` in `Factory.create()`.
+		if receiver := node.Child(0); receiver != nil && receiver.Type() == "simple_identifier" {
+			if name := receiver.Content(w.source); isCapitalized(name) {
+				w.refs = appendUnique(w.refs, w.seen, name)
+			}
+		}
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		w.walk(node.Child(i), enclosing)
+	}
+}
+
+func init() {
+	provider := &KotlinProvider{}
+	Register(".kt", provider)
+	Register(".kts", provider)
+}
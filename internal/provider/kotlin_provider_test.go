@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+func TestKotlinProviderJavaInterop(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"core/Money.java": "package com.acme.core;\npublic final class Money {}\n",
+		"util/StringUtils.kt": `package com.acme.util
+
+fun slugify(s: String): String = s.lowercase()
+
+val separator = "-"
+`,
+		"billing/Invoice.kt": `package com.acme.billing
+
+import com.acme.core.Money
+import com.acme.util.*
+
+data class Invoice(val total: Money) {
+    class Line
+    fun slug() = slugify("invoice")
+}
+`,
+		"billing/InvoiceService.java": `package com.acme.billing;
+
+import com.acme.util.StringUtilsKt;
+
+public class InvoiceService {
+    public Invoice build() { return null; }
+    public String slug() { return StringUtilsKt.slugify("x"); }
+}
+`,
+	})
+
+	kotlin, java := &KotlinProvider{}, &JavaProvider{}
+	eng := engine.New()
+	for _, name := range []string{"core/Money.java", "util/StringUtils.kt", "billing/Invoice.kt", "billing/InvoiceService.java"} {
+		var p Provider = java
+		if filepath.Ext(name) == ".kt" {
+			p = kotlin
+		}
+		dna, err := p.ParseFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("ParseFile(%s) failed: %v", name, err)
+		}
+		eng.IngestFileDNA(dna)
+	}
+	eng.LinkDependencies()
+
+	utils := eng.FileMap[filepath.Join(root, "util/StringUtils.kt")]
+	if !slices.Contains(utils.Exports, "StringUtilsKt") {
+		t.Errorf("expected the StringUtilsKt facade class, got %v", utils.Exports)
+	}
+	invoice := eng.FileMap[filepath.Join(root, "billing/Invoice.kt")]
+	if !slices.Contains(invoice.Exports, "Invoice.Line") {
+		t.Errorf("expected nested type export, got %v", invoice.Exports)
+	}
+
+	for _, edge := range [][2]string{
+		{"core/Money.java", "billing/Invoice.kt"},              // Kotlin importing Java
+		{"util/StringUtils.kt", "billing/Invoice.kt"},          // wildcard-imported top-level function
+		{"util/StringUtils.kt", "billing/InvoiceService.java"}, // Java calling the facade class
+		{"billing/Invoice.kt", "billing/InvoiceService.java"},  // Java using a same-package Kotlin class
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+}
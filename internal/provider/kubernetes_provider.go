@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// KubernetesProvider implements the MultiProvider interface for Kubernetes
+// manifests, including multi-document YAML files.
+//
+// Every object becomes a node keyed "k8s:<Kind>/<namespace>/<name>". Workloads
+// import the ConfigMaps and Secrets they mount or reference from env/envFrom,
+// Services import the workloads whose pod labels match their selector, and
+// Ingresses import their backend Services. YAML files that don't mention
+// apiVersion and kind are skipped without being decoded.
+type KubernetesProvider struct{}
+
+// Ensure KubernetesProvider implements MultiProvider.
+var _ MultiProvider = (*KubernetesProvider)(nil)
+
+// maxSelectorLabels bounds the size of the selectors matched: pods provide a
+// key for every combination of up to this many of their labels. Services with
+// larger selectors are reported and left unlinked.
+const maxSelectorLabels = 4
+
+type k8sObject struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   k8sMetadata `yaml:"metadata"`
+	Spec       k8sSpec     `yaml:"spec"`
+}
+
+type k8sMetadata struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace"`
+	Labels    map[string]string `yaml:"labels"`
+}
+
+// k8sSpec holds the spec fields of the kinds the provider links: Services
+// (selector), workloads (template, jobTemplate), Pods (inline pod spec) and
+// Ingresses (rules, defaultBackend).
+type k8sSpec struct {
+	Selector    map[string]interface{} `yaml:"selector"`
+	Template    *k8sPodTemplate        `yaml:"template"`
+	JobTemplate *struct {
+		Spec struct {
+			Template *k8sPodTemplate `yaml:"template"`
+		} `yaml:"spec"`
+	} `yaml:"jobTemplate"`
+	k8sPodSpec `yaml:",inline"`
+
+	Rules []struct {
+		HTTP struct {
+			Paths []struct {
+				Backend k8sBackend `yaml:"backend"`
+			} `yaml:"paths"`
+		} `yaml:"http"`
+	} `yaml:"rules"`
+	DefaultBackend *k8sBackend `yaml:"defaultBackend"`
+	Backend        *k8sBackend `yaml:"backend"`
+}
+
+type k8sPodTemplate struct {
+	Metadata k8sMetadata `yaml:"metadata"`
+	Spec     k8sPodSpec  `yaml:"spec"`
+}
+
+type k8sPodSpec struct {
+	Containers     []k8sContainer `yaml:"containers"`
+	InitContainers []k8sContainer `yaml:"initContainers"`
+	Volumes        []struct {
+		ConfigMap *k8sRef `yaml:"configMap"`
+		Secret    *struct {
+			SecretName string `yaml:"secretName"`
+		} `yaml:"secret"`
+		Projected *struct {
+			Sources []struct {
+				ConfigMap *k8sRef `yaml:"configMap"`
+				Secret    *k8sRef `yaml:"secret"`
+			} `yaml:"sources"`
+		} `yaml:"projected"`
+	} `yaml:"volumes"`
+}
+
+type k8sContainer struct {
+	EnvFrom []struct {
+		ConfigMapRef *k8sRef `yaml:"configMapRef"`
+		SecretRef    *k8sRef `yaml:"secretRef"`
+	} `yaml:"envFrom"`
+	Env []struct {
+		ValueFrom *struct {
+			ConfigMapKeyRef *k8sRef `yaml:"configMapKeyRef"`
+			SecretKeyRef    *k8sRef `yaml:"secretKeyRef"`
+		} `yaml:"valueFrom"`
+	} `yaml:"env"`
+}
+
+type k8sRef struct {
+	Name string `yaml:"name"`
+}
+
+// k8sBackend covers both networking.k8s.io/v1 and the older extensions
+// Ingress backend forms.
+type k8sBackend struct {
+	Service *k8sRef `yaml:"service"`
+	// ServiceName is the pre-v1 form.
+	ServiceName string `yaml:"serviceName"`
+}
+
+// ParseFile summarises a manifest file as a single node exporting its objects.
+func (p *KubernetesProvider) ParseFile(path string) (*core.FileDNA, error) {
+	nodes, err := p.ParseFileNodes(path)
+	if err != nil || len(nodes) == 0 {
+		return nil, err
+	}
+	return fileSummary(path, "kubernetes", "manifest", nodes), nil
+}
+
+func (p *KubernetesProvider) ParseFileNodes(path string) ([]*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Most YAML in a repository is not a manifest; skip it cheaply.
+	if !bytes.Contains(content, []byte("apiVersion")) || !bytes.Contains(content, []byte("kind")) {
+		return nil, nil
+	}
+
+	var nodes []*core.FileDNA
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var obj k8sObject
+		err := decoder.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var typeErr *yaml.TypeError
+		if err != nil && !errors.As(err, &typeErr) {
+			// Templated manifests (Helm) aren't valid YAML; keep what was read.
+			break
+		}
+		if obj.APIVersion == "" || obj.Kind == "" || obj.Metadata.Name == "" {
+			continue
+		}
+		nodes = append(nodes, k8sNode(path, &obj))
+	}
+	return nodes, nil
+}
+
+func k8sNode(path string, obj *k8sObject) *core.FileDNA {
+	namespace := obj.Metadata.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	key := k8sKey(obj.Kind, namespace, obj.Metadata.Name)
+
+	dna := &core.FileDNA{
+		Path:        path + "/" + namespace + "/" + obj.Kind + "/" + obj.Metadata.Name,
+		Language:    "kubernetes",
+		Kind:        strings.ToLower(obj.Kind),
+		Package:     obj.Metadata.Name,
+		PackagePath: key,
+		Provides:    []string{key},
+		Imports:     []string{},
+		Exports:     []string{},
+		Metadata:    map[string]interface{}{"apiVersion": obj.APIVersion, "namespace": namespace},
+	}
+	seen := make(map[string]bool)
+	addImport := func(kind, name string) {
+		if name != "" {
+			dna.Imports = appendUnique(dna.Imports, seen, k8sKey(kind, namespace, name))
+		}
+	}
+
+	switch obj.Kind {
+	case "Service":
+		if len(obj.Spec.Selector) > 0 {
+			selector := make(map[string]string)
+			for k, v := range obj.Spec.Selector {
+				if s, ok := v.(string); ok {
+					selector[k] = s
+				}
+			}
+			if len(selector) > maxSelectorLabels {
+				fmt.Fprintf(os.Stderr, "Warning: %s: selector of Service %s has more than %d labels and is not matched\n", path, obj.Metadata.Name, maxSelectorLabels)
+				dna.Metadata["unmatchedSelector"] = true
+			} else {
+				dna.Imports = append(dna.Imports, k8sPodsKey(namespace, selector))
+			}
+		}
+	case "Ingress":
+		backends := []*k8sBackend{obj.Spec.DefaultBackend, obj.Spec.Backend}
+		for i := range obj.Spec.Rules {
+			for j := range obj.Spec.Rules[i].HTTP.Paths {
+				backends = append(backends, &obj.Spec.Rules[i].HTTP.Paths[j].Backend)
+			}
+		}
+		for _, backend := range backends {
+			if backend == nil {
+				continue
+			}
+			if backend.Service != nil {
+				addImport("Service", backend.Service.Name)
+			}
+			addImport("Service", backend.ServiceName)
+		}
+	}
+
+	// Workloads carry a pod template; a bare Pod is its own template.
+	template := obj.Spec.Template
+	if obj.Spec.JobTemplate != nil {
+		template = obj.Spec.JobTemplate.Spec.Template
+	}
+	if obj.Kind == "Pod" {
+		template = &k8sPodTemplate{Metadata: obj.Metadata, Spec: obj.Spec.k8sPodSpec}
+	}
+	if template == nil {
+		return dna
+	}
+
+	dna.Provides = append(dna.Provides, k8sLabelKeys(namespace, template.Metadata.Labels)...)
+	spec := template.Spec
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			addImport("ConfigMap", volume.ConfigMap.Name)
+		}
+		if volume.Secret != nil {
+			addImport("Secret", volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					addImport("ConfigMap", source.ConfigMap.Name)
+				}
+				if source.Secret != nil {
+					addImport("Secret", source.Secret.Name)
+				}
+			}
+		}
+	}
+	for _, container := range append(spec.InitContainers, spec.Containers...) {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				addImport("ConfigMap", envFrom.ConfigMapRef.Name)
+			}
+			if envFrom.SecretRef != nil {
+				addImport("Secret", envFrom.SecretRef.Name)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				addImport("ConfigMap", env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				addImport("Secret", env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	return dna
+}
+
+func k8sKey(kind, namespace, name string) string {
+	return "k8s:" + kind + "/" + namespace + "/" + name
+}
+
+// k8sPodsKey identifies the pods a label selector matches.
+func k8sPodsKey(namespace string, labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return "k8s:pods/" + namespace + "/" + strings.Join(pairs, ",")
+}
+
+// k8sLabelKeys returns the pod keys of every combination of up to
+// maxSelectorLabels of a pod's labels, so any selector the pod satisfies
+// resolves to it.
+func k8sLabelKeys(namespace string, labels map[string]string) []string {
+	names := sortedKeys(labels)
+	var keys []string
+	subset := make(map[string]string)
+	var combine func(start int)
+	combine = func(start int) {
+		for i := start; i < len(names); i++ {
+			subset[names[i]] = labels[names[i]]
+			keys = append(keys, k8sPodsKey(namespace, subset))
+			if len(subset) < maxSelectorLabels {
+				combine(i + 1)
+			}
+			delete(subset, names[i])
+		}
+	}
+	combine(0)
+	return keys
+}
+
+func init() {
+	Register(".yaml", &KubernetesProvider{})
+	Register(".yml", &KubernetesProvider{})
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+func TestKubernetesProviderLinksObjects(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"deploy/app.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: shop
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: db-creds
+  namespace: shop
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  template:
+    metadata:
+      labels: {app: web, tier: frontend, team: payments, env: prod, version: v1}
+    spec:
+      containers:
+        - name: web
+          envFrom:
+            - configMapRef: {name: app-config}
+      volumes:
+        - name: creds
+          secret: {secretName: db-creds}
+`,
+		"deploy/network.yaml": `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: shop
+spec:
+  selector: {app: web, tier: frontend}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-canary
+  namespace: shop
+spec:
+  selector: {app: web, tier: frontend, team: payments, env: prod, version: v1}
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+  namespace: shop
+spec:
+  rules:
+    - http:
+        paths:
+          - path: /
+            backend:
+              service: {name: web, port: {number: 80}}
+`,
+		".github/workflows/ci.yml": "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n",
+	})
+
+	p := &KubernetesProvider{}
+	eng := engine.New()
+	for _, name := range []string{"deploy/app.yaml", "deploy/network.yaml", ".github/workflows/ci.yml"} {
+		nodes, err := p.ParseFileNodes(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("ParseFileNodes(%s) failed: %v", name, err)
+		}
+		if name == ".github/workflows/ci.yml" && len(nodes) != 0 {
+			t.Errorf("expected no objects in a non-manifest YAML file, got %d", len(nodes))
+		}
+		for _, dna := range nodes {
+			eng.IngestFileDNA(dna)
+		}
+	}
+	eng.LinkDependencies()
+
+	for _, id := range []string{"deploy/app.yaml/shop/ConfigMap/app-config", "deploy/app.yaml/default/ConfigMap/app-config"} {
+		if eng.FileMap[filepath.Join(root, id)] == nil {
+			t.Errorf("expected a node %s", id)
+		}
+	}
+
+	for _, edge := range [][2]string{
+		{"deploy/app.yaml/shop/ConfigMap/app-config", "deploy/app.yaml/shop/Deployment/web"}, // envFrom
+		{"deploy/app.yaml/shop/Secret/db-creds", "deploy/app.yaml/shop/Deployment/web"},      // volume
+		{"deploy/app.yaml/shop/Deployment/web", "deploy/network.yaml/shop/Service/web"},      // selector
+		{"deploy/network.yaml/shop/Service/web", "deploy/network.yaml/shop/Ingress/web"},     // backend
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+	if hasPathEdge(eng, root, "deploy/app.yaml/default/ConfigMap/app-config", "deploy/app.yaml/shop/Deployment/web") {
+		t.Errorf("references must stay within the object's namespace")
+	}
+
+	canary := eng.FileMap[filepath.Join(root, "deploy/network.yaml/shop/Service/web-canary")]
+	if canary.Metadata["unmatchedSelector"] != true {
+		t.Errorf("expected a selector larger than %d labels to be reported", maxSelectorLabels)
+	}
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+)
+
+// MavenProvider implements the Provider interface for Maven pom.xml files.
+//
+// Each pom is a module node keyed "maven:<groupId>:<artifactId>". It imports
+// its parent and the artifacts in <dependencies>, which link to sibling
+// modules of the same build, and groups every file under its directory (so an
+// aggregator pom groups the modules nested below it).
+type MavenProvider struct{}
+
+// Ensure MavenProvider implements Provider.
+var _ Provider = (*MavenProvider)(nil)
+
+type pomProject struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Parent     struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+	} `xml:"parent"`
+	Dependencies []struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+	} `xml:"dependencies>dependency"`
+}
+
+func (p *MavenProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pom pomProject
+	if err := xml.Unmarshal(content, &pom); err != nil {
+		return nil, err
+	}
+
+	// The groupId is inherited from the parent when omitted.
+	groupID := pom.GroupID
+	if groupID == "" {
+		groupID = pom.Parent.GroupID
+	}
+	expand := func(value string) string {
+		value = strings.ReplaceAll(value, "${project.groupId}", groupID)
+		value = strings.ReplaceAll(value, "${groupId}", groupID)
+		return strings.ReplaceAll(value, "${project.artifactId}", pom.ArtifactID)
+	}
+
+	dir := filepath.Dir(path)
+	dna := &core.FileDNA{
+		Path:        path,
+		Language:    "maven",
+		Kind:        "module",
+		Package:     pom.ArtifactID,
+		PackagePath: "maven:" + groupID + ":" + pom.ArtifactID,
+		GroupDir:    dir,
+		Imports:     []string{},
+		Exports:     []string{},
+		Metadata:    make(map[string]interface{}),
+	}
+
+	seen := make(map[string]bool)
+	if pom.Parent.ArtifactID != "" {
+		dna.Imports = appendUnique(dna.Imports, seen, "maven:"+expand(pom.Parent.GroupID)+":"+expand(pom.Parent.ArtifactID))
+	}
+	for _, dep := range pom.Dependencies {
+		dna.Imports = appendUnique(dna.Imports, seen, "maven:"+expand(dep.GroupID)+":"+expand(dep.ArtifactID))
+	}
+
+	return dna, nil
+}
+
+func init() {
+	RegisterName("pom.xml", &MavenProvider{})
+}
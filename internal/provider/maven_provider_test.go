@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestMavenProviderModules(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"pom.xml": `<project>
+  <groupId>com.acme</groupId>
+  <artifactId>shop</artifactId>
+  <modules><module>core</module><module>api</module></modules>
+</project>
+`,
+		"core/pom.xml": `<project>
+  <parent><groupId>com.acme</groupId><artifactId>shop</artifactId></parent>
+  <artifactId>core</artifactId>
+</project>
+`,
+		"api/pom.xml": `<project>
+  <parent><groupId>com.acme</groupId><artifactId>shop</artifactId></parent>
+  <artifactId>api</artifactId>
+  <dependencies>
+    <dependency><groupId>${project.groupId}</groupId><artifactId>core</artifactId></dependency>
+    <dependency><groupId>org.slf4j</groupId><artifactId>slf4j-api</artifactId></dependency>
+  </dependencies>
+</project>
+`,
+	})
+
+	eng := ingestAll(t, &MavenProvider{}, root, "pom.xml", "core/pom.xml", "api/pom.xml")
+
+	api := eng.FileMap[filepath.Join(root, "api/pom.xml")]
+	if api.PackagePath != "maven:com.acme:api" || api.GroupDir != filepath.Join(root, "api") {
+		t.Errorf("expected module maven:com.acme:api grouping its directory, got %q grouping %q", api.PackagePath, api.GroupDir)
+	}
+	if !slices.Contains(api.Imports, "maven:org.slf4j:slf4j-api") {
+		t.Errorf("expected external dependencies kept as imports, got %v", api.Imports)
+	}
+
+	for _, edge := range [][2]string{
+		{"core/pom.xml", "api/pom.xml"}, // sibling <dependency>
+		{"pom.xml", "api/pom.xml"},      // <parent>
+		{"pom.xml", "core/pom.xml"},
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+)
+
+// MixProvider implements the Provider interface for Elixir mix.exs projects.
+//
+// Each mix.exs is a module node keyed "mix:<app>" that groups every file under
+// its directory, so the apps of an umbrella project become boxes of their own.
+// Dependencies on other apps of the build (`in_umbrella: true` or `path:`)
+// become imports, and the umbrella root imports every app under apps_path.
+type MixProvider struct{}
+
+// Ensure MixProvider implements Provider.
+var _ Provider = (*MixProvider)(nil)
+
+var (
+	mixApp      = regexp.MustCompile(`\bapp:\s*:(\w+)`)
+	mixAppsPath = regexp.MustCompile(`\bapps_path:\s*"([^"]+)"`)
+	mixDep      = regexp.MustCompile(`\{\s*:(\w+)\s*,([^{}]*)\}`)
+	mixLocalDep = regexp.MustCompile(`\b(?:in_umbrella:\s*true|path:)`)
+)
+
+func (p *MixProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	app := mixAppName(dir, content)
+	dna := &core.FileDNA{
+		Path:        path,
+		Language:    "elixir",
+		Kind:        "module",
+		Package:     app,
+		PackagePath: "mix:" + app,
+		GroupDir:    dir,
+		Imports:     []string{},
+		Exports:     []string{},
+		Metadata:    make(map[string]interface{}),
+	}
+
+	seen := make(map[string]bool)
+	for _, m := range mixDep.FindAllStringSubmatch(string(content), -1) {
+		if mixLocalDep.MatchString(m[2]) {
+			dna.Imports = appendUnique(dna.Imports, seen, "mix:"+m[1])
+		}
+	}
+
+	if m := mixAppsPath.FindStringSubmatch(string(content)); m != nil {
+		dna.Metadata["umbrella"] = true
+		manifests, _ := filepath.Glob(filepath.Join(dir, filepath.FromSlash(m[1]), "*", "mix.exs"))
+		for _, manifest := range manifests {
+			if appContent, err := os.ReadFile(manifest); err == nil {
+				dna.Imports = appendUnique(dna.Imports, seen, "mix:"+mixAppName(filepath.Dir(manifest), appContent))
+			}
+		}
+	}
+
+	return dna, nil
+}
+
+// mixAppName returns the `app:` a mix.exs declares, or its directory name for
+// umbrella roots, which declare none.
+func mixAppName(dir string, content []byte) string {
+	if m := mixApp.FindSubmatch(content); m != nil {
+		return string(m[1])
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return filepath.Base(dir)
+}
+
+func init() {
+	RegisterName("mix.exs", &MixProvider{})
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+)
+
+// NotebookProvider implements the Provider interface for Jupyter notebooks.
+// The code cells are joined into a single Python module and analyzed exactly
+// like a .py file by PythonProvider.
+type NotebookProvider struct {
+	// Python resolves module names, sharing its source roots.
+	Python *PythonProvider
+}
+
+// Ensure NotebookProvider implements Provider.
+var _ Provider = (*NotebookProvider)(nil)
+
+// notebook is the subset of the .ipynb format we need.
+type notebook struct {
+	Cells []struct {
+		CellType string          `json:"cell_type"`
+		Source   json.RawMessage `json:"source"`
+	} `json:"cells"`
+}
+
+func (p *NotebookProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var nb notebook
+	if err := json.Unmarshal(content, &nb); err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: "python",
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+
+	filename := filepath.Base(path)
+	dna.Package = strings.TrimSuffix(filename, filepath.Ext(filename))
+
+	var basePackage string
+	dna.PackagePath, basePackage = p.Python.modulePath(path)
+
+	code, cells := notebookCode(nb)
+	dna.Metadata["notebook"] = true
+	dna.Metadata["codeCells"] = cells
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(python.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, code)
+
+	walkPythonTree(tree.RootNode(), code, dna, basePackage)
+
+	return dna, nil
+}
+
+// notebookCode joins the code cells of a notebook into one Python source,
+// dropping IPython-only lines (%magics, %%cell magics and !shell commands).
+// It also returns the number of code cells.
+func notebookCode(nb notebook) ([]byte, int) {
+	var b strings.Builder
+	cells := 0
+	for _, cell := range nb.Cells {
+		if cell.CellType != "code" {
+			continue
+		}
+		cells++
+
+		// "source" is either a single string or a list of lines.
+		var source string
+		var lines []string
+		if err := json.Unmarshal(cell.Source, &source); err != nil {
+			if err := json.Unmarshal(cell.Source, &lines); err != nil {
+				continue
+			}
+			source = strings.Join(lines, "")
+		}
+
+		for _, line := range strings.Split(source, "\n") {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "%") || strings.HasPrefix(trimmed, "!") {
+				continue
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	return []byte(b.String()), cells
+}
+
+func init() {
+	Register(".ipynb", &NotebookProvider{Python: &PythonProvider{}})
+}
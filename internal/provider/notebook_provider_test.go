@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+func TestNotebookProviderCodeCells(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"pyproject.toml":       "[project]\nname = \"analysis\"\n",
+		"analysis/__init__.py": "",
+		"analysis/cleaning.py": "def clean(df):\n    return df\n",
+		"notebooks/explore.ipynb": `{
+ "cells": [
+  {"cell_type": "markdown", "source": ["import markdown_only\n"]},
+  {"cell_type": "code", "source": ["%matplotlib inline\n", "!pip install pandas\n", "import pandas as pd\n"]},
+  {"cell_type": "code", "source": "from analysis.cleaning import clean\n  %time clean(pd.DataFrame())\ndf = clean(pd.DataFrame())"},
+  {"cell_type": "raw", "source": "import raw_only"}
+ ],
+ "metadata": {},
+ "nbformat": 4,
+ "nbformat_minor": 5
+}`,
+	})
+
+	python := &PythonProvider{}
+	notebook := &NotebookProvider{Python: python}
+	eng := engine.New()
+	for _, name := range []string{"analysis/__init__.py", "analysis/cleaning.py", "notebooks/explore.ipynb"} {
+		var p Provider = python
+		if filepath.Ext(name) == ".ipynb" {
+			p = notebook
+		}
+		dna, err := p.ParseFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("ParseFile(%s) failed: %v", name, err)
+		}
+		eng.IngestFileDNA(dna)
+	}
+	eng.LinkDependencies()
+
+	dna := eng.FileMap[filepath.Join(root, "notebooks/explore.ipynb")]
+	if got := dna.Metadata["codeCells"]; got != 2 {
+		t.Errorf("expected 2 code cells, got %v", got)
+	}
+	for _, imp := range []string{"pandas", "analysis.cleaning"} {
+		if !slices.Contains(dna.Imports, imp) {
+			t.Errorf("expected import %q in %v", imp, dna.Imports)
+		}
+	}
+	for _, imp := range dna.Imports {
+		if imp == "markdown_only" || imp == "raw_only" || imp == "pip" {
+			t.Errorf("only code cells should be analyzed, got import %q", imp)
+		}
+	}
+	if !hasPathEdge(eng, root, "analysis/cleaning.py", "notebooks/explore.ipynb") {
+		t.Errorf("expected edge analysis/cleaning.py -> notebooks/explore.ipynb")
+	}
+}
+
+func TestNotebookCodeSkipsIPythonLines(t *testing.T) {
+	var nb notebook
+	source := `{"cells": [{"cell_type": "code", "source": "%load_ext autoreload\n  !ls -la\nimport os\n%%timeit\nos.getcwd()"}]}`
+	if err := json.Unmarshal([]byte(source), &nb); err != nil {
+		t.Fatal(err)
+	}
+
+	code, cells := notebookCode(nb)
+	if cells != 1 {
+		t.Errorf("expected 1 code cell, got %d", cells)
+	}
+	if want := "import os\nos.getcwd()\n\n"; string(code) != want {
+		t.Errorf("expected %q, got %q", want, code)
+	}
+}
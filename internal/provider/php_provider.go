@@ -0,0 +1,356 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/php"
+)
+
+// PHPProvider implements the Provider interface for PHP files.
+//
+// Like Java, a file's namespace is its PackagePath (in dotted form, e.g.
+// "App.Models") and declared types are exported by their short name, so
+// references to "App\Models\User" resolve as "App.Models.User". Besides the
+// types a file declares, the provider exports the class name the composer.json
+// PSR-4 map assigns to the file's path, which is how PHP itself finds the file
+// for a class.
+type PHPProvider struct {
+	mu       sync.Mutex
+	composer map[string]*composerAutoload // directory -> nearest composer.json autoload map
+}
+
+// composerAutoload is the PSR-4 map of a composer.json.
+type composerAutoload struct {
+	dir      string
+	prefixes []psr4Entry // longest namespace prefix first
+}
+
+type psr4Entry struct {
+	prefix string // namespace prefix with trailing backslash, e.g. "App\"
+	dirs   []string
+}
+
+// Ensure PHPProvider implements Provider.
+var _ Provider = (*PHPProvider)(nil)
+
+func (p *PHPProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: "php",
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+
+	filename := filepath.Base(path)
+	dna.Package = strings.TrimSuffix(filename, filepath.Ext(filename))
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(php.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	walker := &phpWalker{
+		source:  content,
+		dna:     dna,
+		aliases: make(map[string]string),
+		seen:    make(map[string]bool),
+	}
+	walker.walk(tree.RootNode())
+
+	if autoload := p.autoloadFor(filepath.Dir(absPath)); autoload != nil {
+		if class := autoload.classFor(absPath); class != "" {
+			dna.Metadata["psr4"] = class
+			namespace, short := phpSplitName(class)
+			if walker.namespace == "" {
+				walker.namespace = namespace
+			}
+			// A class mapped outside the declared namespace can't be keyed
+			// under this file's package; the declaration wins.
+			if namespace == walker.namespace && !walker.declared[short] {
+				dna.Exports = append(dna.Exports, short)
+			}
+		}
+	}
+	dna.PackagePath = phpSymbol(walker.namespace)
+
+	return dna, nil
+}
+
+// autoloadFor returns the PSR-4 map of the nearest composer.json at or above dir.
+func (p *PHPProvider) autoloadFor(dir string) *composerAutoload {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.composer == nil {
+		p.composer = make(map[string]*composerAutoload)
+	}
+
+	var visited []string
+	var autoload *composerAutoload
+	for current := dir; ; {
+		if cached, ok := p.composer[current]; ok {
+			autoload = cached
+			break
+		}
+		visited = append(visited, current)
+		if content, err := os.ReadFile(filepath.Join(current, "composer.json")); err == nil {
+			autoload = parseComposerAutoload(current, content)
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	for _, d := range visited {
+		p.composer[d] = autoload
+	}
+	return autoload
+}
+
+func parseComposerAutoload(dir string, content []byte) *composerAutoload {
+	var manifest struct {
+		Autoload struct {
+			PSR4 map[string]json.RawMessage `json:"psr-4"`
+		} `json:"autoload"`
+		AutoloadDev struct {
+			PSR4 map[string]json.RawMessage `json:"psr-4"`
+		} `json:"autoload-dev"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil
+	}
+
+	autoload := &composerAutoload{dir: dir}
+	for _, psr4 := range []map[string]json.RawMessage{manifest.Autoload.PSR4, manifest.AutoloadDev.PSR4} {
+		for prefix, raw := range psr4 {
+			// A prefix maps to a single directory or a list of them.
+			var dirs []string
+			var single string
+			if err := json.Unmarshal(raw, &single); err == nil {
+				dirs = []string{single}
+			} else if err := json.Unmarshal(raw, &dirs); err != nil {
+				continue
+			}
+			autoload.prefixes = append(autoload.prefixes, psr4Entry{prefix: prefix, dirs: dirs})
+		}
+	}
+	sort.Slice(autoload.prefixes, func(i, j int) bool {
+		return len(autoload.prefixes[i].prefix) > len(autoload.prefixes[j].prefix)
+	})
+	return autoload
+}
+
+// classFor maps a file path to its PSR-4 class name, or "" if no prefix covers it.
+func (a *composerAutoload) classFor(absPath string) string {
+	for _, entry := range a.prefixes {
+		for _, dir := range entry.dirs {
+			rel, err := filepath.Rel(filepath.Join(a.dir, dir), absPath)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			rel = strings.TrimSuffix(filepath.ToSlash(rel), ".php")
+			return entry.prefix + strings.ReplaceAll(rel, "/", "\\")
+		}
+	}
+	return ""
+}
+
+// phpSplitName splits a fully-qualified class name into namespace and short name.
+func phpSplitName(class string) (string, string) {
+	if i := strings.LastIndex(class, "\\"); i != -1 {
+		return class[:i], class[i+1:]
+	}
+	return "", class
+}
+
+// phpSymbol converts a PHP name to the dotted form used for symbol lookup.
+func phpSymbol(name string) string {
+	return strings.ReplaceAll(name, "\\", ".")
+}
+
+// phpWalker collects namespace, use imports, declarations and class references.
+type phpWalker struct {
+	source    []byte
+	dna       *core.FileDNA
+	namespace string
+	aliases   map[string]string // imported short name -> fully-qualified name
+	declared  map[string]bool
+	seen      map[string]bool
+}
+
+func (w *phpWalker) qualify(name string) string {
+	if w.namespace == "" {
+		return name
+	}
+	return w.namespace + "\\" + name
+}
+
+func (w *phpWalker) walk(node *sitter.Node) {
+	if node == nil {
+		return
+	}
+
+	switch node.Type() {
+	case "namespace_definition":
+		if name := node.ChildByFieldName("name"); name != nil {
+			w.namespace = name.Content(w.source)
+		}
+		// Braced namespaces carry their declarations in a body.
+		if body := node.ChildByFieldName("body"); body != nil {
+			w.walk(body)
+		}
+		return
+	case "namespace_use_declaration":
+		w.use(node)
+		return
+	case "class_declaration", "interface_declaration", "trait_declaration", "enum_declaration", "function_definition":
+		if name := node.ChildByFieldName("name"); name != nil {
+			short := name.Content(w.source)
+			if w.declared == nil {
+				w.declared = make(map[string]bool)
+			}
+			w.declared[short] = true
+			w.dna.Exports = append(w.dna.Exports, short)
+		}
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if node.FieldNameForChild(i) != "name" {
+				w.walk(node.Child(i))
+			}
+		}
+		return
+	case "qualified_name", "name":
+		w.reference(node)
+		return
+	case "variable_name", "member_access_expression", "member_call_expression":
+		// Names inside $variables and ->members are never class names.
+		if node.Type() != "variable_name" {
+			if object := node.ChildByFieldName("object"); object != nil {
+				w.walk(object)
+			}
+			if args := node.ChildByFieldName("arguments"); args != nil {
+				w.walk(args)
+			}
+		}
+		return
+	case "scoped_call_expression", "class_constant_access_expression", "scoped_property_access_expression":
+		// Only the class side of Foo::bar() is a reference.
+		if node.ChildCount() > 0 {
+			w.walk(node.Child(0))
+		}
+		if args := node.ChildByFieldName("arguments"); args != nil {
+			w.walk(args)
+		}
+		return
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		w.walk(node.Child(i))
+	}
+}
+
+// use records `use A\B;`, `use A\B as C;` and group uses `use A\{B, C\D as E};`.
+func (w *phpWalker) use(node *sitter.Node) {
+	prefix := ""
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		switch child.Type() {
+		case "namespace_name":
+			prefix = child.Content(w.source) + "\\"
+		case "namespace_use_clause":
+			w.useClause(child, "")
+		case "namespace_use_group":
+			for j := 0; j < int(child.ChildCount()); j++ {
+				if clause := child.Child(j); clause.Type() == "namespace_use_group_clause" {
+					w.useClause(clause, prefix)
+				}
+			}
+		}
+	}
+}
+
+func (w *phpWalker) useClause(clause *sitter.Node, prefix string) {
+	var target, alias string
+	for i := 0; i < int(clause.ChildCount()); i++ {
+		child := clause.Child(i)
+		switch child.Type() {
+		case "qualified_name", "namespace_name", "name":
+			if target == "" {
+				target = child.Content(w.source)
+			} else {
+				alias = child.Content(w.source)
+			}
+		case "namespace_aliasing_clause":
+			for j := 0; j < int(child.ChildCount()); j++ {
+				if child.Child(j).Type() == "name" {
+					alias = child.Child(j).Content(w.source)
+				}
+			}
+		}
+	}
+	if target == "" {
+		return
+	}
+
+	target = prefix + strings.TrimPrefix(target, "\\")
+	if alias == "" {
+		alias = target[strings.LastIndex(target, "\\")+1:]
+	}
+	w.aliases[alias] = target
+	w.dna.Imports = append(w.dna.Imports, phpSymbol(target))
+}
+
+// reference resolves a class name the way PHP does: fully-qualified names as
+// written, imported aliases through the use table, anything else relative to
+// the current namespace.
+func (w *phpWalker) reference(node *sitter.Node) {
+	name := node.Content(w.source)
+	if !isCapitalized(strings.TrimPrefix(name, "\\")) {
+		return
+	}
+
+	var class string
+	switch {
+	case strings.HasPrefix(name, "\\"):
+		class = name[1:]
+	default:
+		first, rest, _ := strings.Cut(name, "\\")
+		if imported, ok := w.aliases[first]; ok {
+			class = imported
+			if rest != "" {
+				class += "\\" + rest
+			}
+		} else {
+			class = w.qualify(name)
+		}
+	}
+
+	if !w.seen[class] {
+		w.seen[class] = true
+		w.dna.Uses = append(w.dna.Uses, phpSymbol(class))
+	}
+}
+
+func init() {
+	Register(".php", &PHPProvider{})
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPHPProviderPSR4(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"composer.json": `{"autoload": {"psr-4": {"App\\": "src/"}}, "autoload-dev": {"psr-4": {"App\\Tests\\": ["tests/"]}}}`,
+		"src/Models/User.php": `<?php
+namespace App\Models;
+
+class User {}
+`,
+		"src/Models/Order.php": `<?php
+namespace App\Models;
+
+class Order { public function owner(): User { return new User(); } }
+`,
+		"src/Http/UserController.php": `<?php
+namespace App\Http;
+
+use App\Models\{Order, User as Account};
+
+class UserController {
+    public function show(Account $user) { return Order::class; }
+}
+`,
+		"tests/UserTest.php": `<?php
+namespace App\Tests;
+
+class UserTest { public function test() { new \App\Models\User(); } }
+`,
+	})
+
+	files := []string{"src/Models/User.php", "src/Models/Order.php", "src/Http/UserController.php", "tests/UserTest.php"}
+	eng := ingestAll(t, &PHPProvider{}, root, files...)
+
+	user := eng.FileMap[filepath.Join(root, "src/Models/User.php")]
+	if user.PackagePath != "App.Models" || !slices.Equal(user.Exports, []string{"User"}) {
+		t.Errorf("expected short export User in App.Models, got %v in %q", user.Exports, user.PackagePath)
+	}
+	if got := user.Metadata["psr4"]; got != `App\Models\User` {
+		t.Errorf("expected PSR-4 class App\\Models\\User, got %v", got)
+	}
+
+	for _, edge := range [][2]string{
+		{"src/Models/User.php", "src/Models/Order.php"},         // same namespace
+		{"src/Models/User.php", "src/Http/UserController.php"},  // aliased group use
+		{"src/Models/Order.php", "src/Http/UserController.php"}, // group use
+		{"src/Models/User.php", "tests/UserTest.php"},           // fully-qualified name
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/protobuf"
+)
+
+// ProtoProvider implements the Provider interface for Protocol Buffers files.
+//
+// Messages, enums, services and RPCs are exported, and `import` statements
+// become imports of the other .proto files. Each file also declares, through
+// FileDNA.Provides, the packages its generated code lives in (go_package,
+// java_package, csharp_namespace and the Python _pb2 modules), so the engine
+// links the .proto to every file importing that generated code.
+type ProtoProvider struct{}
+
+// Ensure ProtoProvider implements Provider.
+var _ Provider = (*ProtoProvider)(nil)
+
+func (p *ProtoProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: "protobuf",
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+
+	filename := filepath.Base(path)
+	dna.Package = strings.TrimSuffix(filename, filepath.Ext(filename))
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(protobuf.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	options := make(map[string]string)
+	root := tree.RootNode()
+	for i := 0; i < int(root.ChildCount()); i++ {
+		child := root.Child(i)
+		switch child.Type() {
+		case "package":
+			for j := 0; j < int(child.ChildCount()); j++ {
+				if ident := child.Child(j); ident.Type() == "full_ident" {
+					dna.PackagePath = ident.Content(content)
+				}
+			}
+		case "import":
+			if pathNode := child.ChildByFieldName("path"); pathNode != nil {
+				dna.Imports = append(dna.Imports, unquote(pathNode.Content(content)))
+			}
+		case "option":
+			var name, value string
+			for j := 0; j < int(child.ChildCount()); j++ {
+				switch part := child.Child(j); part.Type() {
+				case "identifier", "full_ident":
+					name = part.Content(content)
+				case "constant":
+					value = unquote(part.Content(content))
+				}
+			}
+			options[name] = value
+		}
+	}
+
+	walkProtoTree(root, content, dna, "")
+
+	importPath := protoImportPath(path, dna.PackagePath)
+	dna.Metadata["importPath"] = importPath
+	dna.Provides = protoGeneratedPackages(importPath, dna, options)
+
+	return dna, nil
+}
+
+// protoImportPath guesses the path other files use to import this one. When
+// the directories end with the proto package (acme/billing/v1/x.proto for
+// package acme.billing.v1), the import path starts at the package root;
+// otherwise it is just the file name.
+func protoImportPath(path, pkg string) string {
+	filename := filepath.Base(path)
+	dir := filepath.ToSlash(filepath.Dir(path))
+	pkgDir := strings.ReplaceAll(pkg, ".", "/")
+	if pkg != "" && (dir == pkgDir || strings.HasSuffix(dir, "/"+pkgDir)) {
+		return pkgDir + "/" + filename
+	}
+	return filename
+}
+
+// protoGeneratedPackages lists the import paths of the code protoc generates.
+func protoGeneratedPackages(importPath string, dna *core.FileDNA, options map[string]string) []string {
+	// Other .proto files import this one by its import path.
+	provides := []string{importPath}
+
+	if goPackage := options["go_package"]; goPackage != "" {
+		// "github.com/acme/gen/billing/v1;billingv1" -> the part before the package name override.
+		provides = append(provides, strings.SplitN(goPackage, ";", 2)[0])
+	}
+
+	stem := strings.TrimSuffix(importPath, ".proto")
+	pyModule := strings.ReplaceAll(stem, "/", ".")
+	provides = append(provides, pyModule+"_pb2", pyModule+"_pb2_grpc")
+
+	javaPackage := options["java_package"]
+	if javaPackage == "" {
+		javaPackage = dna.PackagePath
+	}
+	if javaPackage != "" {
+		outerClass := options["java_outer_classname"]
+		if outerClass == "" {
+			outerClass = camelize(filepath.Base(stem))
+		}
+		provides = append(provides, javaPackage+"."+outerClass)
+		for _, export := range dna.Exports {
+			if !strings.Contains(export, ".") {
+				provides = append(provides, javaPackage+"."+export)
+			}
+		}
+	}
+
+	csharpNamespace := options["csharp_namespace"]
+	if csharpNamespace == "" && dna.PackagePath != "" {
+		parts := strings.Split(dna.PackagePath, ".")
+		for i, part := range parts {
+			parts[i] = camelize(part)
+		}
+		csharpNamespace = strings.Join(parts, ".")
+	}
+	if csharpNamespace != "" {
+		provides = append(provides, csharpNamespace)
+	}
+
+	return provides
+}
+
+func walkProtoTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, enclosing string) {
+	if node == nil {
+		return
+	}
+
+	var nameType string
+	switch node.Type() {
+	case "message":
+		nameType = "message_name"
+	case "enum":
+		nameType = "enum_name"
+	case "service":
+		nameType = "service_name"
+	case "rpc":
+		nameType = "rpc_name"
+	}
+
+	if nameType != "" {
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if child := node.Child(i); child.Type() == nameType {
+				name := child.Content(sourceCode)
+				if enclosing != "" {
+					name = enclosing + "." + name
+				}
+				dna.Exports = append(dna.Exports, name)
+				enclosing = name
+				break
+			}
+		}
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		walkProtoTree(node.Child(i), sourceCode, dna, enclosing)
+	}
+}
+
+func init() {
+	Register(".proto", &ProtoProvider{})
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+func TestProtoProviderLinksGeneratedCode(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"proto/acme/common/v1/money.proto": `syntax = "proto3";
+package acme.common.v1;
+
+message Money { int64 units = 1; }
+`,
+		"proto/acme/billing/v1/invoice.proto": `syntax = "proto3";
+package acme.billing.v1;
+
+import "acme/common/v1/money.proto";
+
+option go_package = "github.com/acme/gen/billing/v1;billingv1";
+
+message Invoice {
+  message Line { acme.common.v1.Money amount = 1; }
+  repeated Line lines = 1;
+}
+
+service InvoiceService {
+  rpc Get(Invoice) returns (Invoice);
+}
+`,
+		"server/main.go": `package main
+
+import billingv1 "github.com/acme/gen/billing/v1"
+
+var _ billingv1.Invoice
+`,
+		"worker/consume.py": "from acme.billing.v1 import invoice_pb2\n",
+		"web/gen/invoice_pb.ts": `// @generated by protoc-gen-es v1.0.0
+// @generated from file acme/billing/v1/invoice.proto (package acme.billing.v1, syntax proto3)
+export class Invoice {}
+`,
+	})
+
+	eng := engine.New()
+	for _, name := range []string{
+		"proto/acme/common/v1/money.proto", "proto/acme/billing/v1/invoice.proto",
+		"server/main.go", "worker/consume.py", "web/gen/invoice_pb.ts",
+	} {
+		path := filepath.Join(root, name)
+		p, ok := ForFile(path)
+		if !ok {
+			t.Fatalf("no provider for %s", name)
+		}
+		dna, err := p.ParseFile(path)
+		if err != nil {
+			t.Fatalf("ParseFile(%s) failed: %v", name, err)
+		}
+		eng.IngestFileDNA(dna)
+	}
+	eng.LinkDependencies()
+
+	invoice := eng.FileMap[filepath.Join(root, "proto/acme/billing/v1/invoice.proto")]
+	if got := invoice.Metadata["importPath"]; got != "acme/billing/v1/invoice.proto" {
+		t.Errorf("expected import path acme/billing/v1/invoice.proto, got %v", got)
+	}
+	for _, provided := range []string{"github.com/acme/gen/billing/v1", "acme.billing.v1.invoice_pb2", "acme.billing.v1.invoice_pb2_grpc"} {
+		if !slices.Contains(invoice.Provides, provided) {
+			t.Errorf("expected %q in provided packages %v", provided, invoice.Provides)
+		}
+	}
+	if !slices.Contains(invoice.Exports, "Invoice.Line") || !slices.Contains(invoice.Exports, "InvoiceService.Get") {
+		t.Errorf("expected nested message and rpc exports, got %v", invoice.Exports)
+	}
+
+	for _, edge := range [][2]string{
+		{"proto/acme/common/v1/money.proto", "proto/acme/billing/v1/invoice.proto"}, // proto import
+		{"proto/acme/billing/v1/invoice.proto", "server/main.go"},                   // go_package
+		{"proto/acme/billing/v1/invoice.proto", "worker/consume.py"},                // _pb2 module
+		{"proto/acme/billing/v1/invoice.proto", "web/gen/invoice_pb.ts"},            // generated-from comment
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/ritiksrivastava/archhelix/internal/core"
@@ -12,11 +14,28 @@ type Provider interface {
 	ParseFile(path string) (*core.FileDNA, error)
 }
 
+// MultiProvider is implemented by providers whose files declare several graph
+// nodes, such as the tables of a SQL schema. The orchestrator ingests every
+// entry returned by ParseFileNodes instead of the single ParseFile result.
+// Node paths extend the file path ("db/schema.sql/users"), so the UI groups
+// them under the file like the files of a folder.
+type MultiProvider interface {
+	Provider
+	ParseFileNodes(path string) ([]*core.FileDNA, error)
+}
+
 var (
 	registryMu sync.RWMutex
 	registry   = make(map[string]Provider)
+	names      []namedProvider
 )
 
+// namedProvider is a provider registered for a file name pattern.
+type namedProvider struct {
+	pattern  string
+	provider Provider
+}
+
 // Register registers a provider for a specific file extension (e.g., ".go").
 func Register(ext string, p Provider) {
 	registryMu.Lock()
@@ -31,3 +50,58 @@ func Get(ext string) (Provider, bool) {
 	p, ok := registry[ext]
 	return p, ok
 }
+
+// RegisterName registers a provider for files whose base name matches a
+// filepath.Match pattern (e.g., "Dockerfile" or "docker-compose*.yml").
+// Name patterns take precedence over extensions.
+func RegisterName(pattern string, p Provider) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	names = append(names, namedProvider{pattern: pattern, provider: p})
+}
+
+// ForFile returns the provider for a file path, matching registered name
+// patterns first and falling back to the file extension.
+func ForFile(path string) (Provider, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	base := filepath.Base(path)
+	for _, np := range names {
+		if ok, _ := filepath.Match(np.pattern, base); ok {
+			return np.provider, true
+		}
+	}
+	p, ok := registry[filepath.Ext(path)]
+	return p, ok
+}
+
+// fileSummary collapses the nodes of a multi-node file into a single FileDNA
+// for callers of ParseFile: it exports the node names and imports whatever
+// the nodes import from outside the file.
+func fileSummary(path, language, kind string, nodes []*core.FileDNA) *core.FileDNA {
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: language,
+		Kind:     kind,
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+	filename := filepath.Base(path)
+	dna.Package = strings.TrimSuffix(filename, filepath.Ext(filename))
+
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		for _, provided := range node.Provides {
+			seen[provided] = true
+		}
+		dna.Exports = append(dna.Exports, strings.TrimPrefix(node.Path, path+"/"))
+		dna.Provides = append(dna.Provides, node.Provides...)
+	}
+	for _, node := range nodes {
+		for _, imp := range node.Imports {
+			dna.Imports = appendUnique(dna.Imports, seen, imp)
+		}
+	}
+	return dna
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/ritiksrivastava/archhelix/internal/core"
 	sitter "github.com/smacker/go-tree-sitter"
@@ -12,7 +14,20 @@ import (
 )
 
 // PythonProvider implements the Provider interface for Python files.
-type PythonProvider struct{}
+//
+// Module names are relative to a source root: one of SourceRoots, a root
+// declared by the enclosing project (pyproject.toml, setup.cfg or setup.py,
+// defaulting to src/ like setuptools), or the project directory itself.
+// Directories need no __init__.py, so PEP 420 namespace packages resolve too.
+// Files outside any project are named after the path they were passed as
+// (relative to the working directory when the scan path was relative).
+type PythonProvider struct {
+	// SourceRoots are extra source roots, e.g. from the --python-root flag.
+	SourceRoots []string
+
+	mu       sync.Mutex
+	projects map[string][]string // directory -> source roots of the enclosing project (nil if none)
+}
 
 // Ensure PythonProvider implements Provider.
 var _ Provider = (*PythonProvider)(nil)
@@ -47,19 +62,188 @@ func (p *PythonProvider) ParseFile(path string) (*core.FileDNA, error) {
 		dna.Package = filename
 	}
 
-	// Calculate a logical PackagePath (Python module name) based on the file path
-	// e.g. "src/utils/db.py" -> "src.utils.db"
-	// "__init__.py" in "src/utils" -> "src.utils"
+	var basePackage string
+	dna.PackagePath, basePackage = p.modulePath(path)
+
+	walkPythonTree(tree.RootNode(), content, dna, basePackage)
+
+	return dna, nil
+}
+
+// modulePath returns the module name and relative-import base of a file,
+// computed from the most specific source root containing it.
+func (p *PythonProvider) modulePath(path string) (string, string) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return pythonModulePath(path)
+	}
+
+	roots := p.projectRoots(filepath.Dir(absPath))
+	for _, root := range p.SourceRoots {
+		if absRoot, err := filepath.Abs(root); err == nil {
+			roots = append(roots, absRoot)
+		}
+	}
+
+	best := ""
+	for _, root := range roots {
+		if strings.HasPrefix(absPath, root+string(filepath.Separator)) && len(root) > len(best) {
+			best = root
+		}
+	}
+	if best == "" {
+		return pythonModulePath(path)
+	}
+	rel, _ := filepath.Rel(best, absPath)
+	return pythonModulePath(rel)
+}
+
+// projectRoots returns the source roots of the nearest project at or above
+// dir, followed by the project directory itself.
+func (p *PythonProvider) projectRoots(dir string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.projects == nil {
+		p.projects = make(map[string][]string)
+	}
+
+	var visited []string
+	var roots []string
+	for current := dir; ; {
+		if cached, ok := p.projects[current]; ok {
+			roots = cached
+			break
+		}
+		visited = append(visited, current)
+		if declared, ok := pythonProjectRoots(current); ok {
+			roots = append(declared, current)
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	for _, d := range visited {
+		p.projects[d] = roots
+	}
+	return roots
+}
+
+var (
+	setupPyPackageDir = regexp.MustCompile(`package_dir\s*=\s*\{\s*["']["']\s*:\s*["']([^"']+)["']`)
+	setupPyFind       = regexp.MustCompile(`find_(?:namespace_)?packages\(\s*(?:where\s*=\s*)?["']([^"']+)["']`)
+	poetryFrom        = regexp.MustCompile(`from\s*=\s*["']([^"']+)["']`)
+)
+
+// pythonProjectRoots reads the source roots a project declares in
+// pyproject.toml, setup.cfg or setup.py. It reports false if dir holds none
+// of these files.
+func pythonProjectRoots(dir string) ([]string, bool) {
+	var declared []string
+	found := false
+
+	if content, err := os.ReadFile(filepath.Join(dir, "pyproject.toml")); err == nil {
+		found = true
+		values := readTOML(content)
+		declared = append(declared, tomlStrings(values, "tool.setuptools.packages.find.where")...)
+		// package-dir = {"" = "src"}
+		declared = append(declared, tomlStrings(values, "tool.setuptools.package-dir.")...)
+		for _, pkg := range tomlStrings(values, "tool.poetry.packages") {
+			if m := poetryFrom.FindStringSubmatch(pkg); m != nil {
+				declared = append(declared, m[1])
+			}
+		}
+		for _, pkg := range tomlStrings(values, "tool.hatch.build.targets.wheel.packages") {
+			declared = append(declared, filepath.Dir(pkg))
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "setup.cfg")); err == nil {
+		found = true
+		values := readINI(content)
+		declared = append(declared, strings.Fields(values["options.packages.find.where"])...)
+		// package_dir =\n    =src
+		for _, line := range strings.Split(values["options.package_dir"], "\n") {
+			if key, value, ok := strings.Cut(line, "="); ok && strings.TrimSpace(key) == "" {
+				declared = append(declared, strings.TrimSpace(value))
+			}
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "setup.py")); err == nil {
+		found = true
+		for _, re := range []*regexp.Regexp{setupPyPackageDir, setupPyFind} {
+			for _, m := range re.FindAllStringSubmatch(string(content), -1) {
+				declared = append(declared, m[1])
+			}
+		}
+	}
+
+	if !found {
+		return nil, false
+	}
+
+	// Like setuptools' automatic discovery, fall back to a src/ layout.
+	if len(declared) == 0 {
+		if info, err := os.Stat(filepath.Join(dir, "src")); err == nil && info.IsDir() {
+			if _, err := os.Stat(filepath.Join(dir, "src", "__init__.py")); err != nil {
+				declared = append(declared, "src")
+			}
+		}
+	}
+
+	var roots []string
+	for _, root := range declared {
+		if root != "" && root != "." {
+			roots = append(roots, filepath.Join(dir, filepath.FromSlash(root)))
+		}
+	}
+	return roots, true
+}
+
+// readINI parses an INI file such as setup.cfg into a map keyed by
+// "section.key". Indented continuation lines are joined with newlines.
+func readINI(content []byte) map[string]string {
+	values := make(map[string]string)
+	section, key := "", ""
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";"):
+		case strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"):
+			section, key = strings.TrimSpace(trimmed[1:len(trimmed)-1]), ""
+		case line[0] == ' ' || line[0] == '\t':
+			if key != "" {
+				values[key] += "\n" + trimmed
+			}
+		default:
+			name, value, _ := strings.Cut(trimmed, "=")
+			key = section + "." + strings.TrimSpace(name)
+			values[key] = strings.TrimSpace(value)
+		}
+	}
+	return values
+}
+
+// pythonModulePath calculates a logical PackagePath (Python module name) based on
+// the file path and the base package relative imports are resolved against.
+// e.g. "src/utils/db.py" -> "src.utils.db"
+// "__init__.py" in "src/utils" -> "src.utils"
+func pythonModulePath(path string) (string, string) {
 	normalizedPath := filepath.ToSlash(path)
-	normalizedPath = strings.TrimSuffix(normalizedPath, ".py")
+	normalizedPath = strings.TrimSuffix(normalizedPath, filepath.Ext(normalizedPath))
 	if strings.HasSuffix(normalizedPath, "/__init__") {
 		normalizedPath = strings.TrimSuffix(normalizedPath, "/__init__")
 	} else if normalizedPath == "__init__" {
 		normalizedPath = ""
 	}
-	dna.PackagePath = strings.ReplaceAll(normalizedPath, "/", ".")
+	packagePath := strings.ReplaceAll(normalizedPath, "/", ".")
 
-	basePackage := dna.PackagePath
+	basePackage := packagePath
 	if filepath.Base(path) != "__init__.py" {
 		lastDot := strings.LastIndex(basePackage, ".")
 		if lastDot != -1 {
@@ -68,10 +252,7 @@ func (p *PythonProvider) ParseFile(path string) (*core.FileDNA, error) {
 			basePackage = ""
 		}
 	}
-
-	walkPythonTree(tree.RootNode(), content, dna, basePackage)
-
-	return dna, nil
+	return packagePath, basePackage
 }
 
 func resolveRelativeImport(basePackage string, relImport string) string {
@@ -105,24 +286,118 @@ func resolveRelativeImport(basePackage string, relImport string) string {
 	return resolvedBase + "." + suffix
 }
 
+// pythonWalker collects imports, definitions and the qualified uses of
+// imported names from a Python syntax tree.
+//
+// Imports are tagged with the context they appear in (see core.ImportKinds):
+// under `if TYPE_CHECKING:`, in a try/except ImportError fallback, or inside a
+// function. Uses through an alias inherit the kind of its import.
+type pythonWalker struct {
+	source      []byte
+	dna         *core.FileDNA
+	basePackage string
+	aliases     map[string]string // local name -> module or symbol it is bound to
+	aliasKinds  map[string]string // local name -> kind of the import binding it
+	kinds       map[string]string // import or use -> strongest kind seen
+	kind        string            // kind of the context being walked
+	used        map[string]bool
+	imported    map[string]string // name bound by a module-level from-import -> symbol it refers to
+	starModules []string          // modules of module-level `from m import *`
+}
+
 func walkPythonTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, basePackage string) {
 	if node == nil {
 		return
 	}
+	w := &pythonWalker{
+		source:      sourceCode,
+		dna:         dna,
+		basePackage: basePackage,
+		aliases:     make(map[string]string),
+		aliasKinds:  make(map[string]string),
+		kinds:       make(map[string]string),
+		used:        make(map[string]bool),
+		imported:    make(map[string]string),
+	}
+	// Imports are collected first so names used above a late import still resolve.
+	w.walkDefinitions(node)
+	w.walkUses(node)
+	w.recordReExports(node)
+}
 
+// atModuleLevel reports whether the walker is outside functions and
+// type-checking blocks, where an imported name becomes a module attribute.
+// Optional imports are too, e.g. a try/except ImportError fallback.
+func (w *pythonWalker) atModuleLevel() bool {
+	return w.kind == "" || w.kind == core.ImportOptional
+}
+
+// recordReExports records the names a module re-exports: those listed in
+// __all__, or else every module-level from-import of a package __init__.py.
+func (w *pythonWalker) recordReExports(root *sitter.Node) {
+	all, hasAll := pythonAll(root, w.source)
+	if !hasAll && filepath.Base(w.dna.Path) != "__init__.py" {
+		return
+	}
+	for name, symbol := range w.imported {
+		// `from . import sub` in pkg/__init__.py is the submodule itself.
+		if (hasAll && !all[name]) || symbol == w.dna.PackagePath+"."+name {
+			continue
+		}
+		if w.dna.ReExports == nil {
+			w.dna.ReExports = make(map[string]string)
+		}
+		w.dna.ReExports[name] = symbol
+	}
+	w.dna.ReExportsAll = w.starModules
+}
+
+// pythonAll returns the names listed in a module's __all__, and whether it
+// declares one.
+func pythonAll(root *sitter.Node, sourceCode []byte) (map[string]bool, bool) {
+	names := make(map[string]bool)
+	found := false
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		stmt := root.NamedChild(i)
+		if stmt.Type() != "expression_statement" || stmt.NamedChildCount() == 0 {
+			continue
+		}
+		// __all__ = [...] or __all__ += [...]
+		assignment := stmt.NamedChild(0)
+		if assignment.Type() != "assignment" && assignment.Type() != "augmented_assignment" {
+			continue
+		}
+		left, right := assignment.ChildByFieldName("left"), assignment.ChildByFieldName("right")
+		if left == nil || right == nil || left.Content(sourceCode) != "__all__" {
+			continue
+		}
+		found = true
+		for j := 0; j < int(right.NamedChildCount()); j++ {
+			if name, exact := pythonModuleName(right.NamedChild(j), sourceCode); exact {
+				names[name] = true
+			}
+		}
+	}
+	return names, found
+}
+
+func (w *pythonWalker) walkDefinitions(node *sitter.Node) {
 	switch node.Type() {
 	case "import_statement":
 		for i := 0; i < int(node.ChildCount()); i++ {
 			child := node.Child(i)
 			if child.Type() == "dotted_name" {
-				dna.Imports = append(dna.Imports, child.Content(sourceCode))
+				module := child.Content(w.source)
+				w.addImport(module)
+				// "import a.b.c" binds the top-level package "a".
+				top := strings.SplitN(module, ".", 2)[0]
+				w.bind(top, top)
 			} else if child.Type() == "aliased_import" {
-				// aliased_import has a dotted_name child
-				for j := 0; j < int(child.ChildCount()); j++ {
-					grandchild := child.Child(j)
-					if grandchild.Type() == "dotted_name" {
-						dna.Imports = append(dna.Imports, grandchild.Content(sourceCode))
-						break
+				if name := child.ChildByFieldName("name"); name != nil {
+					module := name.Content(w.source)
+					w.addImport(module)
+					if alias := child.ChildByFieldName("alias"); alias != nil {
+						w.bind(alias.Content(w.source), module)
 					}
 				}
 			}
@@ -135,35 +410,36 @@ func walkPythonTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, bas
 			if child.Type() == "import" {
 				seenImportKeyword = true
 				if moduleName != "" {
-					dna.Imports = append(dna.Imports, moduleName)
+					w.addImport(moduleName)
 				}
 			} else if !seenImportKeyword {
 				if child.Type() == "dotted_name" || child.Type() == "identifier" {
-					moduleName = child.Content(sourceCode)
+					moduleName = child.Content(w.source)
 				} else if child.Type() == "relative_import" {
-					moduleName = resolveRelativeImport(basePackage, child.Content(sourceCode))
+					moduleName = resolveRelativeImport(w.basePackage, child.Content(w.source))
 				}
 			} else if seenImportKeyword {
-				if child.Type() == "dotted_name" || child.Type() == "identifier" {
-					name := child.Content(sourceCode)
-					if moduleName != "" {
-						dna.Imports = append(dna.Imports, moduleName+"."+name)
-					} else {
-						dna.Imports = append(dna.Imports, name)
-					}
-				} else if child.Type() == "aliased_import" {
-					for j := 0; j < int(child.ChildCount()); j++ {
-						grandchild := child.Child(j)
-						if grandchild.Type() == "dotted_name" || grandchild.Type() == "identifier" {
-							name := grandchild.Content(sourceCode)
-							if moduleName != "" {
-								dna.Imports = append(dna.Imports, moduleName+"."+name)
-							} else {
-								dna.Imports = append(dna.Imports, name)
-							}
-							break
-						}
-					}
+				if child.Type() == "wildcard_import" && moduleName != "" && w.atModuleLevel() {
+					w.starModules = append(w.starModules, moduleName)
+					continue
+				}
+				nameNode, aliasNode := child, child
+				if child.Type() == "aliased_import" {
+					nameNode, aliasNode = child.ChildByFieldName("name"), child.ChildByFieldName("alias")
+				} else if child.Type() != "dotted_name" && child.Type() != "identifier" {
+					continue
+				}
+				if nameNode == nil || aliasNode == nil {
+					continue
+				}
+				name := nameNode.Content(w.source)
+				if moduleName != "" {
+					name = moduleName + "." + name
+				}
+				w.addImport(name)
+				w.bind(aliasNode.Content(w.source), name)
+				if w.atModuleLevel() {
+					w.imported[aliasNode.Content(w.source)] = name
 				}
 			}
 		}
@@ -171,15 +447,259 @@ func walkPythonTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, bas
 		for i := 0; i < int(node.ChildCount()); i++ {
 			child := node.Child(i)
 			if child.Type() == "identifier" {
-				dna.Exports = append(dna.Exports, child.Content(sourceCode))
+				w.dna.Exports = append(w.dna.Exports, child.Content(w.source))
 				break
 			}
 		}
+	case "call":
+		w.addDynamicImport(node)
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		outer := w.kind
+		w.kind = core.WeakerImportKind(outer, pythonImportContext(node, child, w.source))
+		w.walkDefinitions(child)
+		w.kind = outer
+	}
+}
+
+var pythonImportErrors = regexp.MustCompile(`\b(ImportError|ModuleNotFoundError)\b`)
+
+// pythonImportContext returns the import kind a child block of node puts its
+// imports under, or "" if it is an ordinary block.
+func pythonImportContext(node, child *sitter.Node, sourceCode []byte) string {
+	switch node.Type() {
+	case "function_definition":
+		if child == node.ChildByFieldName("body") {
+			return core.ImportLazy
+		}
+	case "if_statement":
+		// `if TYPE_CHECKING:` or `if typing.TYPE_CHECKING:`
+		condition := node.ChildByFieldName("condition")
+		if child == node.ChildByFieldName("consequence") && condition != nil {
+			if chain := pythonAttributeChain(condition, sourceCode); chain != nil && chain[len(chain)-1] == "TYPE_CHECKING" {
+				return core.ImportType
+			}
+		}
+	case "try_statement":
+		// Both the attempted import and the fallback in the handler are optional.
+		if child.Type() == "except_clause" && pythonCatchesImportError(child, sourceCode) {
+			return core.ImportOptional
+		}
+		if child == node.ChildByFieldName("body") {
+			for i := 0; i < int(node.ChildCount()); i++ {
+				if handler := node.Child(i); handler.Type() == "except_clause" && pythonCatchesImportError(handler, sourceCode) {
+					return core.ImportOptional
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// pythonCatchesImportError reports whether an except clause handles a failed
+// import, by type rather than by looking into its block.
+func pythonCatchesImportError(clause *sitter.Node, sourceCode []byte) bool {
+	for i := 0; i < int(clause.ChildCount()); i++ {
+		if child := clause.Child(i); child.Type() != "block" && pythonImportErrors.MatchString(child.Content(sourceCode)) {
+			return true
+		}
+	}
+	return false
+}
+
+// addDynamicImport records the module loaded by an importlib.import_module()
+// or __import__() call. A name built from a constant prefix, such as
+// "app.plugins." + name, becomes a prefix import of every module under it.
+func (w *pythonWalker) addDynamicImport(call *sitter.Node) {
+	fn, args := call.ChildByFieldName("function"), call.ChildByFieldName("arguments")
+	if fn == nil || args == nil {
+		return
+	}
+	chain := pythonAttributeChain(fn, w.source)
+	if chain == nil {
+		return
+	}
+	name := strings.Join(chain, ".")
+	if target, ok := w.aliases[chain[0]]; ok {
+		name = strings.Join(append([]string{target}, chain[1:]...), ".")
+	}
+	if name != "importlib.import_module" && name != "__import__" {
+		return
+	}
+
+	var module string
+	exact := false
+	for i := 0; i < int(args.NamedChildCount()); i++ {
+		if arg := args.NamedChild(i); arg.Type() != "keyword_argument" && arg.Type() != "comment" {
+			module, exact = pythonModuleName(arg, w.source)
+			break
+		}
+	}
+	if module == "" {
+		return
+	}
+	if strings.HasPrefix(module, ".") {
+		// import_module(".sub", __package__) is relative like `from . import sub`.
+		resolved := resolveRelativeImport(w.basePackage, module)
+		if strings.Trim(module, ".") == "" && resolved != "" {
+			resolved += "."
+		}
+		module = resolved
+	}
+	if module == "" {
+		return
+	}
+
+	if exact {
+		w.dna.DynamicImports = append(w.dna.DynamicImports, module)
+		w.tag(module, w.kind)
+	} else {
+		w.dna.DynamicImports = append(w.dna.DynamicImports, module+"*")
+		w.tag(module+"*", core.WeakerImportKind(w.kind, core.ImportDynamic))
+	}
+}
+
+// pythonModuleName evaluates a module name expression. It returns the whole
+// name of a string literal (exact), or the constant prefix of a name built by
+// concatenation, %-formatting, str.format() or an f-string.
+func pythonModuleName(node *sitter.Node, sourceCode []byte) (string, bool) {
+	switch node.Type() {
+	case "string":
+		var b strings.Builder
+		for i := 0; i < int(node.ChildCount()); i++ {
+			switch child := node.Child(i); child.Type() {
+			case "string_content":
+				b.WriteString(child.Content(sourceCode))
+			case "interpolation":
+				return b.String(), false
+			}
+		}
+		return b.String(), true
+	case "binary_operator":
+		left, operator := node.ChildByFieldName("left"), node.ChildByFieldName("operator")
+		if left == nil || operator == nil {
+			return "", false
+		}
+		prefix, _ := pythonModuleName(left, sourceCode)
+		if operator.Type() == "%" {
+			prefix, _, _ = strings.Cut(prefix, "%")
+		} else if operator.Type() != "+" {
+			return "", false
+		}
+		return prefix, false
+	case "call":
+		// "app.plugins.{}".format(name)
+		if fn := node.ChildByFieldName("function"); fn != nil && fn.Type() == "attribute" {
+			object, attr := fn.ChildByFieldName("object"), fn.ChildByFieldName("attribute")
+			if object != nil && attr != nil && attr.Content(sourceCode) == "format" {
+				template, _ := pythonModuleName(object, sourceCode)
+				prefix, _, _ := strings.Cut(template, "{")
+				return prefix, false
+			}
+		}
+	case "parenthesized_expression":
+		if node.NamedChildCount() == 1 {
+			return pythonModuleName(node.NamedChild(0), sourceCode)
+		}
+	}
+	return "", false
+}
+
+func (w *pythonWalker) addImport(module string) {
+	w.dna.Imports = append(w.dna.Imports, module)
+	w.tag(module, w.kind)
+}
+
+func (w *pythonWalker) bind(name, target string) {
+	kind := w.kind
+	if current, ok := w.aliasKinds[name]; ok {
+		kind = core.StrongerImportKind(current, kind)
+	}
+	w.aliases[name] = target
+	w.aliasKinds[name] = kind
+}
+
+// tag records the kind of an import or use; the strongest occurrence wins.
+func (w *pythonWalker) tag(symbol, kind string) {
+	if current, ok := w.kinds[symbol]; ok {
+		kind = core.StrongerImportKind(current, kind)
+	}
+	w.kinds[symbol] = kind
+	if kind == "" {
+		delete(w.dna.ImportKinds, symbol)
+		return
+	}
+	if w.dna.ImportKinds == nil {
+		w.dna.ImportKinds = make(map[string]string)
+	}
+	w.dna.ImportKinds[symbol] = kind
+}
+
+// walkUses records references to imported names as qualified uses:
+// with `import numpy as np`, `np.linalg.norm(x)` uses "numpy.linalg" and
+// "numpy.linalg.norm".
+func (w *pythonWalker) walkUses(node *sitter.Node) {
+	switch node.Type() {
+	case "import_statement", "import_from_statement":
+		return
+	case "attribute":
+		if chain := pythonAttributeChain(node, w.source); chain != nil {
+			if target, ok := w.aliases[chain[0]]; ok {
+				for _, attr := range chain[1:] {
+					target += "." + attr
+					w.use(target, w.aliasKinds[chain[0]])
+				}
+			}
+			return
+		}
+		// A chain on a call or subscript: only the object can refer to an import.
+		if object := node.ChildByFieldName("object"); object != nil {
+			w.walkUses(object)
+		}
+		return
+	case "identifier":
+		if target, ok := w.aliases[node.Content(w.source)]; ok {
+			w.use(target, w.aliasKinds[node.Content(w.source)])
+		}
+		return
+	case "function_definition", "class_definition", "keyword_argument":
+		// Skip the name being defined, but not the body or the argument value.
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if child := node.Child(i); child != node.ChildByFieldName("name") {
+				w.walkUses(child)
+			}
+		}
+		return
 	}
 
 	for i := 0; i < int(node.ChildCount()); i++ {
-		walkPythonTree(node.Child(i), sourceCode, dna, basePackage)
+		w.walkUses(node.Child(i))
+	}
+}
+
+func (w *pythonWalker) use(symbol, kind string) {
+	w.dna.Uses = appendUnique(w.dna.Uses, w.used, symbol)
+	w.tag(symbol, kind)
+}
+
+// pythonAttributeChain flattens `a.b.c` into ["a", "b", "c"]. It returns nil
+// unless the chain starts with a plain name (not a call or subscript).
+func pythonAttributeChain(node *sitter.Node, sourceCode []byte) []string {
+	switch node.Type() {
+	case "identifier":
+		return []string{node.Content(sourceCode)}
+	case "attribute":
+		object, attr := node.ChildByFieldName("object"), node.ChildByFieldName("attribute")
+		if object == nil || attr == nil {
+			return nil
+		}
+		if chain := pythonAttributeChain(object, sourceCode); chain != nil {
+			return append(chain, attr.Content(sourceCode))
+		}
 	}
+	return nil
 }
 
 func init() {
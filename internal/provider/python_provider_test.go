@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+)
+
+func TestPythonProviderQualifiesUses(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"main.py": `import numpy as np
+import os.path
+from app.services import mail
+from app.db import connect, session as s
+
+def run():
+    np.linalg.norm([1])
+    os.path.join("a")
+    mail.Mailer().send()
+    s.query()
+    connect()
+`,
+	})
+
+	dna, err := (&PythonProvider{}).ParseFile(filepath.Join(root, "main.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, use := range []string{
+		"numpy.linalg",
+		"numpy.linalg.norm",
+		"os.path.join",
+		"app.services.mail.Mailer",
+		"app.db.session.query",
+		"app.db.connect",
+	} {
+		if !slices.Contains(dna.Uses, use) {
+			t.Errorf("expected use %q in %v", use, dna.Uses)
+		}
+	}
+	if slices.Contains(dna.Uses, "numpy") {
+		t.Errorf("attribute chains should not also use the bare module: %v", dna.Uses)
+	}
+}
+
+func TestPythonProviderImportKinds(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"main.py": `import typing
+from app import config
+if typing.TYPE_CHECKING:
+    from app.models import User
+    import app.config
+try:
+    import ujson as json
+except ImportError:
+    import json
+
+def handler(user: User):
+    from app import tasks
+    tasks.run(json.dumps(user))
+`,
+	})
+
+	dna, err := (&PythonProvider{}).ParseFile(filepath.Join(root, "main.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"typing":          "",
+		"app.config":      "", // also imported at the top level
+		"app.models.User": core.ImportType,
+		"ujson":           core.ImportOptional,
+		"json":            core.ImportOptional,
+		"json.dumps":      core.ImportOptional,
+		"app.tasks":       core.ImportLazy,
+		"app.tasks.run":   core.ImportLazy,
+	}
+	for symbol, kind := range want {
+		if got := dna.ImportKinds[symbol]; got != kind {
+			t.Errorf("%s: expected kind %q, got %q", symbol, kind, got)
+		}
+	}
+}
+
+func TestPythonProviderDynamicImports(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"main.py": `import importlib as il
+from importlib import import_module
+
+backend = il.import_module("app.backends.redis")
+legacy = __import__("app.legacy")
+
+def load(name):
+    import_module("app.plugins." + name)
+    import_module(f"app.hooks.{name}")
+    import_module("app.tasks.%s" % name)
+    import_module(name)
+`,
+	})
+
+	dna, err := (&PythonProvider{}).ParseFile(filepath.Join(root, "main.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"app.backends.redis", "app.legacy", "app.plugins.*", "app.hooks.*", "app.tasks.*"}
+	if !slices.Equal(dna.DynamicImports, want) {
+		t.Errorf("expected dynamic imports %v, got %v", want, dna.DynamicImports)
+	}
+	if kind := dna.ImportKinds["app.plugins.*"]; kind != core.ImportDynamic {
+		t.Errorf("expected prefix import of kind %q, got %q", core.ImportDynamic, kind)
+	}
+}
+
+func TestPythonProviderReExports(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"pyproject.toml": "",
+		"pkg/__init__.py": `from .impl import Client, _helper
+from .models import *
+from . import impl
+try:
+    from .fast import Parser
+except ImportError:
+    from .slow import Parser
+
+def connect():
+    from .db import Session
+`,
+		"pkg/api.py": `from pkg.impl import Client
+from pkg.db import Session
+__all__ = ["Client"]
+`,
+	})
+
+	p := &PythonProvider{}
+	dna, err := p.ParseFile(filepath.Join(root, "pkg/__init__.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"Client": "pkg.impl.Client", "_helper": "pkg.impl._helper", "Parser": "pkg.slow.Parser"}
+	if !maps.Equal(dna.ReExports, want) {
+		t.Errorf("expected re-exports %v, got %v", want, dna.ReExports)
+	}
+	if !slices.Equal(dna.ReExportsAll, []string{"pkg.models"}) {
+		t.Errorf("expected wildcard re-export of pkg.models, got %v", dna.ReExportsAll)
+	}
+
+	// Outside __init__.py only the names in __all__ are re-exported.
+	dna, err = p.ParseFile(filepath.Join(root, "pkg/api.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"Client": "pkg.impl.Client"}; !maps.Equal(dna.ReExports, want) {
+		t.Errorf("expected re-exports %v, got %v", want, dna.ReExports)
+	}
+}
+
+func TestPythonProviderSourceRoots(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"pyproject.toml":          "[tool.setuptools.packages.find]\nwhere = [\"src\"]\n",
+		"src/acme/core/util.py":   "def helper(): pass\n",
+		"src/acme/plugins/a.py":   "from acme.core.util import helper\nhelper()\n",
+		"scripts/tools/cli.py":    "import acme.core.util\n",
+		"extra/vendored/thing.py": "def thing(): pass\n",
+	})
+
+	p := &PythonProvider{SourceRoots: []string{filepath.Join(root, "extra")}}
+	want := map[string]string{
+		"src/acme/core/util.py":   "acme.core.util",
+		"src/acme/plugins/a.py":   "acme.plugins.a",
+		"scripts/tools/cli.py":    "scripts.tools.cli",
+		"extra/vendored/thing.py": "vendored.thing",
+	}
+	for name, packagePath := range want {
+		dna, err := p.ParseFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dna.PackagePath != packagePath {
+			t.Errorf("%s: expected module %q, got %q", name, packagePath, dna.PackagePath)
+		}
+	}
+}
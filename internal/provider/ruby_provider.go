@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/ruby"
+)
+
+// RubyProvider implements the Provider interface for Ruby files.
+//
+// Files are keyed by their absolute path (without ".rb") so that require and
+// require_relative resolve to them. Most Rails code never requires its own
+// files, so the provider also derives each file's constant from the Zeitwerk
+// naming convention (app/*/billing/invoice_builder.rb -> Billing::InvoiceBuilder)
+// and records referenced constants as Uses. Acronyms declared with
+// inflect.acronym in config/initializers/inflections.rb are honoured
+// (api/v1/users_controller.rb -> API::V1::UsersController).
+type RubyProvider struct {
+	mu       sync.Mutex
+	roots    map[string]string            // directory -> project root (Gemfile or .gemspec), "" if none
+	acronyms map[string]map[string]string // project root -> lowercase word -> acronym
+}
+
+// Ensure RubyProvider implements Provider.
+var _ Provider = (*RubyProvider)(nil)
+
+// rubyNonAutoloadDirs are app/ subdirectories Zeitwerk does not autoload.
+var rubyNonAutoloadDirs = map[string]bool{"assets": true, "javascript": true, "views": true}
+
+// rubyAcronymPattern matches `inflect.acronym "API"` declarations.
+var rubyAcronymPattern = regexp.MustCompile(`\.acronym\s*\(?\s*["']([^"']+)["']`)
+
+func (p *RubyProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:        path,
+		Language:    "ruby",
+		PackagePath: filepath.ToSlash(strings.TrimSuffix(absPath, ".rb")),
+		Imports:     []string{},
+		Exports:     []string{},
+		Metadata:    make(map[string]interface{}),
+	}
+
+	filename := filepath.Base(path)
+	dna.Package = strings.TrimSuffix(filename, filepath.Ext(filename))
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(ruby.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	projectRoot := p.projectRoot(filepath.Dir(absPath))
+	walker := &rubyWalker{
+		source:      content,
+		dna:         dna,
+		dir:         filepath.Dir(absPath),
+		projectRoot: projectRoot,
+		exported:    make(map[string]bool),
+		used:        make(map[string]bool),
+	}
+	walker.walk(tree.RootNode(), nil)
+
+	if constant := zeitwerkConstant(projectRoot, absPath, p.inflections(projectRoot)); constant != "" {
+		dna.Metadata["constant"] = constant
+		if !walker.exported[constant] {
+			dna.Exports = append(dna.Exports, constant)
+		}
+	}
+
+	return dna, nil
+}
+
+// projectRoot returns the nearest directory at or above dir holding a Gemfile or gemspec.
+func (p *RubyProvider) projectRoot(dir string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.roots == nil {
+		p.roots = make(map[string]string)
+	}
+
+	var visited []string
+	root := ""
+	for current := dir; ; {
+		if cached, ok := p.roots[current]; ok {
+			root = cached
+			break
+		}
+		visited = append(visited, current)
+		gemspecs, _ := filepath.Glob(filepath.Join(current, "*.gemspec"))
+		if _, err := os.Stat(filepath.Join(current, "Gemfile")); err == nil || len(gemspecs) > 0 {
+			root = current
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	for _, d := range visited {
+		p.roots[d] = root
+	}
+	return root
+}
+
+// inflections returns the acronyms declared in the project's
+// config/initializers/inflections.rb, keyed by their lowercase form.
+func (p *RubyProvider) inflections(projectRoot string) map[string]string {
+	if projectRoot == "" {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if acronyms, ok := p.acronyms[projectRoot]; ok {
+		return acronyms
+	}
+	if p.acronyms == nil {
+		p.acronyms = make(map[string]map[string]string)
+	}
+
+	acronyms := make(map[string]string)
+	if content, err := os.ReadFile(filepath.Join(projectRoot, "config", "initializers", "inflections.rb")); err == nil {
+		for _, m := range rubyAcronymPattern.FindAllStringSubmatch(string(content), -1) {
+			acronyms[strings.ToLower(m[1])] = m[1]
+		}
+	}
+	p.acronyms[projectRoot] = acronyms
+	return acronyms
+}
+
+// zeitwerkConstant derives the constant a file defines under Zeitwerk conventions.
+// Autoload roots are every app/* directory (plus their concerns/) and lib/.
+func zeitwerkConstant(projectRoot, absPath string, acronyms map[string]string) string {
+	if projectRoot == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(projectRoot, absPath)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(filepath.ToSlash(strings.TrimSuffix(rel, ".rb")), "/")
+
+	switch {
+	case len(parts) >= 3 && parts[0] == "app" && !rubyNonAutoloadDirs[parts[1]]:
+		parts = parts[2:]
+		if len(parts) > 1 && parts[0] == "concerns" {
+			parts = parts[1:]
+		}
+	case len(parts) >= 2 && parts[0] == "lib":
+		parts = parts[1:]
+	default:
+		return ""
+	}
+
+	for i, part := range parts {
+		parts[i] = rubyCamelize(part, acronyms)
+	}
+	return strings.Join(parts, "::")
+}
+
+// camelize converts snake_case to CamelCase ("invoice_builder" -> "InvoiceBuilder").
+func camelize(s string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(s, "_") {
+		if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// rubyCamelize camelizes like camelize but keeps declared acronyms whole
+// ("api_client" -> "APIClient" when "API" is an acronym).
+func rubyCamelize(s string, acronyms map[string]string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(s, "_") {
+		if acronym, ok := acronyms[word]; ok {
+			b.WriteString(acronym)
+		} else if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// rubyWalker collects requires, declared constants and constant references.
+type rubyWalker struct {
+	source      []byte
+	dna         *core.FileDNA
+	dir         string
+	projectRoot string
+	exported    map[string]bool
+	used        map[string]bool
+}
+
+func (w *rubyWalker) walk(node *sitter.Node, nesting []string) {
+	if node == nil {
+		return
+	}
+
+	switch node.Type() {
+	case "call":
+		if method := node.ChildByFieldName("method"); method != nil && node.ChildByFieldName("receiver") == nil {
+			switch method.Content(w.source) {
+			case "require", "require_relative", "load":
+				if target := w.requireTarget(node, method.Content(w.source) == "require_relative"); target != "" {
+					w.dna.Imports = append(w.dna.Imports, target)
+				}
+				return
+			}
+		}
+	case "class", "module":
+		nameNode := node.ChildByFieldName("name")
+		if nameNode == nil {
+			break
+		}
+		name := strings.TrimPrefix(nameNode.Content(w.source), "::")
+		if len(nesting) > 0 && !strings.HasPrefix(nameNode.Content(w.source), "::") {
+			name = nesting[len(nesting)-1] + "::" + name
+		}
+		// Modules that only wrap nested declarations are namespaces shared by
+		// many files; exporting them would link every file in the namespace.
+		if !rubyIsNamespaceOnly(node) && !w.exported[name] {
+			w.exported[name] = true
+			w.dna.Exports = append(w.dna.Exports, name)
+		}
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if node.FieldNameForChild(i) != "name" {
+				w.walk(node.Child(i), append(nesting, name))
+			}
+		}
+		return
+	case "scope_resolution", "constant":
+		w.reference(node.Content(w.source), nesting)
+		return
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		w.walk(node.Child(i), nesting)
+	}
+}
+
+// requireTarget resolves the string argument of a require call to a file key.
+// Library requires that don't map to a project file are kept as written.
+func (w *rubyWalker) requireTarget(call *sitter.Node, relative bool) string {
+	args := call.ChildByFieldName("arguments")
+	if args == nil {
+		return ""
+	}
+	var target string
+	for i := 0; i < int(args.ChildCount()); i++ {
+		if arg := args.Child(i); arg.Type() == "string" {
+			target = strings.TrimSuffix(unquote(arg.Content(w.source)), ".rb")
+			break
+		}
+	}
+	if target == "" {
+		return ""
+	}
+
+	if relative {
+		return filepath.ToSlash(filepath.Join(w.dir, target))
+	}
+	if w.projectRoot != "" {
+		for _, dir := range []string{"lib", "app", ""} {
+			candidate := filepath.Join(w.projectRoot, dir, target)
+			if _, err := os.Stat(candidate + ".rb"); err == nil {
+				return filepath.ToSlash(candidate)
+			}
+		}
+	}
+	return target
+}
+
+// reference records a constant reference under every name Ruby's lexical
+// lookup could resolve it to, innermost nesting first.
+func (w *rubyWalker) reference(name string, nesting []string) {
+	if !isCapitalized(strings.TrimPrefix(name, "::")) {
+		return
+	}
+	candidates := []string{strings.TrimPrefix(name, "::")}
+	if !strings.HasPrefix(name, "::") {
+		for i := len(nesting) - 1; i >= 0; i-- {
+			candidates = append(candidates, nesting[i]+"::"+name)
+		}
+	}
+	for _, candidate := range candidates {
+		if !w.used[candidate] {
+			w.used[candidate] = true
+			w.dna.Uses = append(w.dna.Uses, candidate)
+		}
+	}
+}
+
+// rubyIsNamespaceOnly reports whether a class/module body holds nothing but
+// nested class/module declarations.
+func rubyIsNamespaceOnly(node *sitter.Node) bool {
+	if node.Type() != "module" {
+		return false
+	}
+	body := node.ChildByFieldName("body")
+	if body == nil {
+		return false
+	}
+	for i := 0; i < int(body.ChildCount()); i++ {
+		switch body.Child(i).Type() {
+		case "class", "module", "comment":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	Register(".rb", &RubyProvider{})
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRubyProviderZeitwerkConstants(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"Gemfile":                                 "source \"https://rubygems.org\"\n",
+		"config/initializers/inflections.rb":      "ActiveSupport::Inflector.inflections(:en) do |inflect|\n  inflect.acronym \"API\"\n  inflect.acronym('HTML')\nend\n",
+		"app/controllers/api/users_controller.rb": "module API\n  class UsersController\n    def index\n      Billing::InvoiceBuilder.new\n      HTMLRenderer.call\n    end\n  end\nend\n",
+		"app/services/billing/invoice_builder.rb": "module Billing\n  class InvoiceBuilder\n  end\nend\n",
+		"lib/html_renderer.rb":                    "require_relative \"support/helpers\"\n\nclass HTMLRenderer\nend\n",
+		"lib/support/helpers.rb":                  "module Helpers\nend\n",
+	})
+
+	files := []string{
+		"app/controllers/api/users_controller.rb", "app/services/billing/invoice_builder.rb",
+		"lib/html_renderer.rb", "lib/support/helpers.rb",
+	}
+	eng := ingestAll(t, &RubyProvider{}, root, files...)
+
+	want := map[string]string{
+		"app/controllers/api/users_controller.rb": "API::UsersController",
+		"app/services/billing/invoice_builder.rb": "Billing::InvoiceBuilder",
+		"lib/html_renderer.rb":                    "HTMLRenderer",
+	}
+	for name, constant := range want {
+		if got := eng.FileMap[filepath.Join(root, name)].Metadata["constant"]; got != constant {
+			t.Errorf("%s: expected constant %q, got %v", name, constant, got)
+		}
+	}
+
+	for _, edge := range [][2]string{
+		{"app/services/billing/invoice_builder.rb", "app/controllers/api/users_controller.rb"},
+		{"lib/html_renderer.rb", "app/controllers/api/users_controller.rb"},
+		{"lib/support/helpers.rb", "lib/html_renderer.rb"}, // require_relative
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+}
@@ -0,0 +1,527 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/rust"
+)
+
+// RustProvider implements the Provider interface for Rust files.
+//
+// Rust module paths come from `mod` declarations rather than from a statement in
+// each file, so the provider walks the module tree of every crate once, starting
+// at its crate roots (lib.rs, main.rs, src/bin/*.rs), and derives a dotted
+// PackagePath for each file (e.g. "my_crate.net.http"), the same way the Python
+// provider derives module names. Binary targets (main.rs, src/bin/*.rs) live
+// under "<crate>.bin.<name>" so they don't collide with the library.
+//
+// The crate root groups the files of its package and imports the sibling
+// crates of its workspace that it depends on.
+type RustProvider struct {
+	mu     sync.Mutex
+	crates map[string]*rustCrate // manifest directory -> crate
+}
+
+// rustCrate describes a Cargo package and its module tree.
+type rustCrate struct {
+	once      sync.Once
+	dir       string
+	name      string            // crate name with "-" replaced by "_"
+	workspace string            // workspace root directory, if the crate is a workspace member
+	root      string            // file of the library target, or of the main binary without one
+	deps      []string          // crates depended on by path, e.g. workspace siblings
+	modules   map[string]string // absolute file path -> module path
+	targets   map[string]string // absolute file path -> module path of its target's root
+}
+
+// Ensure RustProvider implements Provider.
+var _ Provider = (*RustProvider)(nil)
+
+func (p *RustProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: "rust",
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	crateRoot := ""
+	crate := p.crateFor(absPath)
+	if crate != nil {
+		dna.Package = crate.name
+		dna.PackagePath = crate.modulePath(absPath)
+		crateRoot = crate.targetRoot(absPath)
+		if crate.workspace != "" {
+			dna.Metadata["workspace"] = crate.workspace
+		}
+		if absPath == crate.root {
+			rel, _ := filepath.Rel(filepath.Dir(absPath), crate.dir)
+			dna.GroupDir = filepath.Join(filepath.Dir(path), rel)
+			dna.Imports = append(dna.Imports, crate.deps...)
+		}
+	} else {
+		// Loose file outside any Cargo package: treat it as its own crate.
+		filename := filepath.Base(path)
+		dna.Package = strings.TrimSuffix(filename, filepath.Ext(filename))
+		dna.PackagePath = dna.Package
+		crateRoot = dna.PackagePath
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(rust.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	root := tree.RootNode()
+	ctx := &rustScope{
+		crate:   crateRoot,
+		module:  dna.PackagePath,
+		locals:  rustChildModules(root, content),
+		symbols: make(map[string]bool),
+	}
+	walkRustTree(root, content, dna, ctx, "")
+
+	return dna, nil
+}
+
+// crateFor returns the crate owning the file, loading its module tree on first use.
+func (p *RustProvider) crateFor(absPath string) *rustCrate {
+	manifestDir := ""
+	var manifest map[string]interface{}
+	for current := filepath.Dir(absPath); ; {
+		if content, err := os.ReadFile(filepath.Join(current, "Cargo.toml")); err == nil {
+			values := readTOML(content)
+			if tomlValue(values, "package.name") != "" {
+				manifestDir, manifest = current, values
+				break
+			}
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil
+		}
+		current = parent
+	}
+
+	p.mu.Lock()
+	if p.crates == nil {
+		p.crates = make(map[string]*rustCrate)
+	}
+	crate, ok := p.crates[manifestDir]
+	if !ok {
+		crate = &rustCrate{dir: manifestDir}
+		p.crates[manifestDir] = crate
+	}
+	p.mu.Unlock()
+
+	crate.once.Do(func() {
+		crate.name = strings.ReplaceAll(tomlValue(manifest, "package.name"), "-", "_")
+		crate.workspace = findRustWorkspace(manifestDir)
+		crate.deps = rustPathDependencies(manifest, crate.workspace)
+		crate.modules = make(map[string]string)
+		crate.targets = make(map[string]string)
+
+		libRoot := filepath.Join(manifestDir, "src", "lib.rs")
+		if libPath := tomlValue(manifest, "lib.path"); libPath != "" {
+			libRoot = filepath.Join(manifestDir, libPath)
+		}
+		roots := map[string]string{
+			libRoot: crate.name,
+			filepath.Join(manifestDir, "src", "main.rs"): crate.name + ".bin." + crate.name,
+		}
+		bins, _ := filepath.Glob(filepath.Join(manifestDir, "src", "bin", "*.rs"))
+		for _, bin := range bins {
+			roots[bin] = crate.name + ".bin." + strings.TrimSuffix(filepath.Base(bin), ".rs")
+		}
+
+		visited := make(map[string]bool)
+		for _, root := range append([]string{libRoot}, sortedKeys(roots)...) {
+			if _, err := os.Stat(root); err != nil || visited[root] {
+				continue
+			}
+			if crate.root == "" && (root == libRoot || filepath.Base(root) == "main.rs") {
+				crate.root = root
+			}
+			crate.modules[root] = roots[root]
+			crate.walkModules(root, filepath.Dir(root), roots[root], roots[root], visited)
+		}
+	})
+	return crate
+}
+
+var rustDependencySections = []string{"dependencies", "dev-dependencies", "build-dependencies"}
+
+// rustPathDependencies returns the crates a manifest depends on by path,
+// directly or through the workspace's [workspace.dependencies].
+func rustPathDependencies(manifest map[string]interface{}, workspace string) []string {
+	var inherited map[string]interface{}
+	if workspace != "" {
+		if content, err := os.ReadFile(filepath.Join(workspace, "Cargo.toml")); err == nil {
+			inherited = readTOML(content)
+		}
+	}
+
+	var deps []string
+	seen := make(map[string]bool)
+	for _, key := range sortedKeys(manifest) {
+		for _, section := range rustDependencySections {
+			name, ok := strings.CutPrefix(key, section+".")
+			if !ok {
+				continue
+			}
+			name, field, _ := strings.Cut(name, ".")
+			isPath := field == "path"
+			if field == "workspace" && tomlValue(manifest, key) == "true" {
+				isPath = tomlValue(inherited, "workspace.dependencies."+name+".path") != ""
+			}
+			if !isPath {
+				continue
+			}
+			// `foo = { package = "foo-core", path = ... }` renames the crate.
+			if renamed := tomlValue(manifest, section+"."+name+".package"); renamed != "" {
+				name = renamed
+			}
+			deps = appendUnique(deps, seen, strings.ReplaceAll(name, "-", "_"))
+		}
+	}
+	return deps
+}
+
+// findRustWorkspace returns the root of the Cargo workspace listing dir as a
+// member, or "" if dir is not a workspace member.
+func findRustWorkspace(dir string) string {
+	for current := filepath.Dir(dir); ; {
+		if content, err := os.ReadFile(filepath.Join(current, "Cargo.toml")); err == nil {
+			for _, member := range tomlStrings(readTOML(content), "workspace.members") {
+				matches, _ := filepath.Glob(filepath.Join(current, member))
+				for _, match := range matches {
+					if match == dir {
+						return current
+					}
+				}
+			}
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return ""
+		}
+		current = parent
+	}
+}
+
+// walkModules follows the `mod name;` declarations of file and records the module
+// path of every file they load. childDir is where the children of file live.
+func (c *rustCrate) walkModules(file, childDir, modPath, target string, visited map[string]bool) {
+	if visited[file] {
+		return
+	}
+	visited[file] = true
+	c.targets[file] = target
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return
+	}
+	parser := sitter.NewParser()
+	parser.SetLanguage(rust.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	c.walkModItems(tree.RootNode(), content, childDir, filepath.Dir(file), modPath, target, visited)
+}
+
+// walkModItems follows the `mod` items of a file or inline module body.
+// #[path] attributes resolve against pathDir: the file's own directory at the
+// top level, childDir inside inline modules.
+func (c *rustCrate) walkModItems(node *sitter.Node, content []byte, childDir, pathDir, modPath, target string, visited map[string]bool) {
+	var pathAttr string
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		switch child.Type() {
+		case "attribute_item":
+			// Other attributes (#[cfg], #[allow]) may sit between #[path] and the mod.
+			if value := rustPathAttribute(child, content); value != "" {
+				pathAttr = value
+			}
+			continue
+		case "line_comment", "block_comment":
+			continue
+		case "mod_item":
+			nameNode := child.ChildByFieldName("name")
+			if nameNode == nil {
+				break
+			}
+			name := nameNode.Content(content)
+			childPath := modPath + "." + name
+
+			if body := child.ChildByFieldName("body"); body != nil {
+				// Inline module: its file-backed children live in a subdirectory.
+				inlineDir := filepath.Join(childDir, name)
+				c.walkModItems(body, content, inlineDir, inlineDir, childPath, target, visited)
+				break
+			}
+
+			candidates := []string{
+				filepath.Join(childDir, name+".rs"),
+				filepath.Join(childDir, name, "mod.rs"),
+			}
+			if pathAttr != "" {
+				candidates = []string{filepath.Join(pathDir, pathAttr)}
+			}
+			for _, candidate := range candidates {
+				if _, err := os.Stat(candidate); err != nil {
+					continue
+				}
+				if _, seen := c.modules[candidate]; !seen {
+					c.modules[candidate] = childPath
+				}
+				grandchildDir := filepath.Dir(candidate)
+				if filepath.Base(candidate) != "mod.rs" && pathAttr == "" {
+					grandchildDir = filepath.Join(grandchildDir, name)
+				}
+				c.walkModules(candidate, grandchildDir, childPath, target, visited)
+				break
+			}
+		}
+		pathAttr = ""
+	}
+}
+
+// modulePath returns the module path of a file, falling back to its location
+// under src/ for files that no `mod` declaration reaches.
+func (c *rustCrate) modulePath(absPath string) string {
+	if modPath, ok := c.modules[absPath]; ok {
+		return modPath
+	}
+	rel, err := filepath.Rel(filepath.Join(c.dir, "src"), absPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel, _ = filepath.Rel(c.dir, absPath)
+	}
+	rel = strings.TrimSuffix(filepath.ToSlash(rel), ".rs")
+	rel = strings.TrimSuffix(rel, "/mod")
+	switch rel {
+	case "lib", "mod":
+		return c.name
+	case "main":
+		return c.name + ".bin." + c.name
+	}
+	return c.name + "." + strings.ReplaceAll(rel, "/", ".")
+}
+
+// targetRoot returns the module path `crate::` refers to in a file: the
+// root of the library or binary target that loads it.
+func (c *rustCrate) targetRoot(absPath string) string {
+	if target, ok := c.targets[absPath]; ok {
+		return target
+	}
+	return c.name
+}
+
+func rustPathAttribute(node *sitter.Node, content []byte) string {
+	for i := 0; i < int(node.ChildCount()); i++ {
+		attr := node.Child(i)
+		if attr.Type() != "attribute" || attr.ChildCount() == 0 || attr.Child(0).Content(content) != "path" {
+			continue
+		}
+		if value := attr.ChildByFieldName("value"); value != nil {
+			return unquote(value.Content(content))
+		}
+	}
+	return ""
+}
+
+// rustScope carries what is needed to turn a `use` path into a dotted module path.
+type rustScope struct {
+	crate   string
+	module  string
+	locals  map[string]bool // child modules declared in the current file
+	symbols map[string]bool
+}
+
+// rustStdCrates are crates shipped with the toolchain; they never map to repository files.
+var rustStdCrates = map[string]bool{"std": true, "core": true, "alloc": true}
+
+// rustChildModules returns the names of modules declared at the top level of a file.
+func rustChildModules(root *sitter.Node, content []byte) map[string]bool {
+	locals := make(map[string]bool)
+	for i := 0; i < int(root.ChildCount()); i++ {
+		child := root.Child(i)
+		if child.Type() == "mod_item" {
+			if name := child.ChildByFieldName("name"); name != nil {
+				locals[name.Content(content)] = true
+			}
+		}
+	}
+	return locals
+}
+
+// resolve converts Rust path segments into a dotted module path.
+func (s *rustScope) resolve(segments []string) string {
+	if len(segments) == 0 {
+		return ""
+	}
+	base := strings.Split(s.module, ".")
+	switch segments[0] {
+	case "crate":
+		base = []string{s.crate}
+		segments = segments[1:]
+	case "self":
+		segments = segments[1:]
+	case "super":
+		for len(segments) > 0 && segments[0] == "super" {
+			if len(base) > 1 {
+				base = base[:len(base)-1]
+			}
+			segments = segments[1:]
+		}
+	default:
+		if !s.locals[segments[0]] {
+			// Another crate, e.g. a workspace sibling or a dependency.
+			base = []string{strings.ReplaceAll(segments[0], "-", "_")}
+			segments = segments[1:]
+		}
+	}
+	return strings.Join(append(append([]string{}, base...), segments...), ".")
+}
+
+// rustSegments flattens a scoped path node into its segments.
+func rustSegments(node *sitter.Node, content []byte) []string {
+	switch node.Type() {
+	case "scoped_identifier", "scoped_type_identifier":
+		var segments []string
+		if path := node.ChildByFieldName("path"); path != nil {
+			segments = rustSegments(path, content)
+		}
+		if name := node.ChildByFieldName("name"); name != nil {
+			segments = append(segments, name.Content(content))
+		}
+		return segments
+	case "identifier", "type_identifier", "crate", "self", "super":
+		return []string{node.Content(content)}
+	}
+	return nil
+}
+
+// rustUseTargets expands a use tree (lists, aliases, globs) into full paths.
+func rustUseTargets(node *sitter.Node, content []byte, prefix []string) [][]string {
+	switch node.Type() {
+	case "scoped_use_list":
+		if path := node.ChildByFieldName("path"); path != nil {
+			prefix = append(append([]string{}, prefix...), rustSegments(path, content)...)
+		}
+		if list := node.ChildByFieldName("list"); list != nil {
+			return rustUseTargets(list, content, prefix)
+		}
+	case "use_list":
+		var targets [][]string
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if child := node.Child(i); child.IsNamed() {
+				targets = append(targets, rustUseTargets(child, content, prefix)...)
+			}
+		}
+		return targets
+	case "use_as_clause":
+		if path := node.ChildByFieldName("path"); path != nil {
+			return rustUseTargets(path, content, prefix)
+		}
+	case "use_wildcard":
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if child := node.Child(i); child.IsNamed() {
+				return rustUseTargets(child, content, prefix)
+			}
+		}
+		return [][]string{prefix}
+	case "self":
+		// `use a::{self}` imports `a` itself.
+		if len(prefix) > 0 {
+			return [][]string{prefix}
+		}
+		return [][]string{{"self"}}
+	default:
+		if segments := rustSegments(node, content); segments != nil {
+			return [][]string{append(append([]string{}, prefix...), segments...)}
+		}
+	}
+	return nil
+}
+
+func walkRustTree(node *sitter.Node, sourceCode []byte, dna *core.FileDNA, scope *rustScope, enclosing string) {
+	if node == nil {
+		return
+	}
+
+	switch node.Type() {
+	case "use_declaration":
+		if arg := node.ChildByFieldName("argument"); arg != nil {
+			for _, target := range rustUseTargets(arg, sourceCode, nil) {
+				if resolved := scope.resolve(target); resolved != "" {
+					dna.Imports = append(dna.Imports, resolved)
+				}
+			}
+		}
+		return
+	case "mod_item":
+		nameNode := node.ChildByFieldName("name")
+		if nameNode == nil {
+			break
+		}
+		name := nameNode.Content(sourceCode)
+		if enclosing != "" {
+			name = enclosing + "." + name
+		}
+		dna.Exports = append(dna.Exports, name)
+		if body := node.ChildByFieldName("body"); body != nil {
+			// Items of inline modules are exported with the module prefix.
+			for i := 0; i < int(body.ChildCount()); i++ {
+				walkRustTree(body.Child(i), sourceCode, dna, scope, name)
+			}
+		} else if enclosing == "" {
+			// `mod name;` makes the parent depend on the file backing the child module.
+			dna.Imports = append(dna.Imports, dna.PackagePath+"."+name)
+		}
+		return
+	case "function_item", "struct_item", "enum_item", "union_item", "trait_item",
+		"type_item", "const_item", "static_item", "macro_definition":
+		if nameNode := node.ChildByFieldName("name"); nameNode != nil {
+			name := nameNode.Content(sourceCode)
+			if enclosing != "" {
+				name = enclosing + "." + name
+			}
+			dna.Exports = append(dna.Exports, name)
+		}
+	case "scoped_identifier", "scoped_type_identifier":
+		// Qualified paths in code such as `crate::db::connect()` are precise usages.
+		// Paths starting with a type (e.g. `Vec::new`) or the standard library are skipped.
+		segments := rustSegments(node, sourceCode)
+		if len(segments) > 1 && !isCapitalized(segments[0]) && !rustStdCrates[segments[0]] {
+			if use := scope.resolve(segments); !scope.symbols[use] {
+				scope.symbols[use] = true
+				dna.Uses = append(dna.Uses, use)
+			}
+		}
+		return
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		walkRustTree(node.Child(i), sourceCode, dna, scope, enclosing)
+	}
+}
+
+func init() {
+	Register(".rs", &RustProvider{})
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+// ingestAll parses the named files under root with p and links them.
+func ingestAll(t *testing.T, p Provider, root string, names ...string) *engine.Engine {
+	t.Helper()
+	eng := engine.New()
+	for _, name := range names {
+		dna, err := p.ParseFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("ParseFile(%s) failed: %v", name, err)
+		}
+		eng.IngestFileDNA(dna)
+	}
+	eng.LinkDependencies()
+	return eng
+}
+
+// hasPathEdge reports an edge between two nodes given by their path under root.
+func hasPathEdge(eng *engine.Engine, root, source, target string) bool {
+	for _, edge := range eng.GetGraph().Edges {
+		if edge.Source == filepath.Join(root, source) && edge.Target == filepath.Join(root, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRustProviderTargetsAndWorkspace(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"Cargo.toml":                 "[workspace]\nmembers = [\"crates/*\"]\n\n[workspace.dependencies]\nnet-core = { path = \"crates/net-core\" }\n",
+		"crates/app/Cargo.toml":      "[package]\nname = \"app\"\n\n[dependencies]\nnet-core.workspace = true\nserde = \"1\"\n",
+		"crates/app/src/lib.rs":      "pub mod config;\npub mod a;\n",
+		"crates/app/src/a.rs":        "#[path = \"other.rs\"]\npub mod c;\n#[path = \"third.rs\"]\n// unix only\n#[cfg(unix)]\nmod d;\n",
+		"crates/app/src/a/other.rs":  "pub struct Decoy;\n",
+		"crates/app/src/other.rs":    "pub struct C;\n",
+		"crates/app/src/third.rs":    "pub struct D;\n",
+		"crates/app/src/config.rs":   "pub struct Config;\n",
+		"crates/app/src/main.rs":     "mod cli;\nuse app::config::Config;\nfn main() { crate::cli::run(); }\n",
+		"crates/app/src/cli.rs":      "pub fn run() {}\n",
+		"crates/app/src/bin/tool.rs": "fn main() {}\n",
+		"crates/net-core/Cargo.toml": "[package]\nname = \"net-core\"\n",
+		"crates/net-core/src/lib.rs": "pub fn connect() {}\n",
+	})
+
+	files := []string{
+		"crates/app/src/lib.rs", "crates/app/src/config.rs", "crates/app/src/main.rs",
+		"crates/app/src/cli.rs", "crates/app/src/bin/tool.rs", "crates/net-core/src/lib.rs",
+		"crates/app/src/a.rs", "crates/app/src/a/other.rs", "crates/app/src/other.rs", "crates/app/src/third.rs",
+	}
+	eng := ingestAll(t, &RustProvider{}, root, files...)
+
+	want := map[string]string{
+		"crates/app/src/lib.rs":      "app",
+		"crates/app/src/main.rs":     "app.bin.app",
+		"crates/app/src/cli.rs":      "app.bin.app.cli",
+		"crates/app/src/bin/tool.rs": "app.bin.tool",
+		"crates/app/src/other.rs":    "app.a.c", // #[path] resolves against the declaring file's directory
+		"crates/app/src/third.rs":    "app.a.d", // ... even with other attributes and comments in between
+		"crates/app/src/a/other.rs":  "app.a.other",
+	}
+	for name, packagePath := range want {
+		if got := eng.FileMap[filepath.Join(root, name)].PackagePath; got != packagePath {
+			t.Errorf("%s: expected module %q, got %q", name, packagePath, got)
+		}
+	}
+
+	for _, edge := range [][2]string{
+		{"crates/app/src/cli.rs", "crates/app/src/main.rs"},     // crate:: in a binary
+		{"crates/app/src/config.rs", "crates/app/src/main.rs"},  // the binary using its library
+		{"crates/net-core/src/lib.rs", "crates/app/src/lib.rs"}, // workspace dependency
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+
+	lib := eng.FileMap[filepath.Join(root, "crates/app/src/lib.rs")]
+	if lib.GroupDir != filepath.Join(root, "crates/app") {
+		t.Errorf("expected the library root to group its crate, got %q", lib.GroupDir)
+	}
+	if slices.Contains(lib.Imports, "serde") {
+		t.Errorf("registry dependencies are not workspace edges: %v", lib.Imports)
+	}
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+// SFCProvider implements the Provider interface for single-file components
+// (.vue and .svelte). The <script> blocks are analyzed with the same logic as
+// JSTSProvider, and components used in the template are recorded as Uses:
+// resolved through the script's imports (and a Vue `components:` option) to
+// the module they come from, or by bare name if nothing in the script binds them.
+type SFCProvider struct {
+	// Script resolves the imports of <script> blocks, sharing its tsconfig cache.
+	Script *JSTSProvider
+}
+
+// Ensure SFCProvider implements Provider.
+var _ Provider = (*SFCProvider)(nil)
+
+var (
+	sfcScriptRe    = regexp.MustCompile(`(?is)<script\b([^>]*)>(.*?)</script>`)
+	sfcStyleRe     = regexp.MustCompile(`(?is)<style\b[^>]*>.*?</style>`)
+	sfcCommentRe   = regexp.MustCompile(`(?s)<!--.*?-->`)
+	sfcLangRe      = regexp.MustCompile(`\blang\s*=\s*["']([a-z]+)["']`)
+	sfcComponentRe = regexp.MustCompile(`<([A-Z][A-Za-z0-9]*(?:\.[A-Z][A-Za-z0-9]*)*|[a-z][a-z0-9]*(?:-[a-z0-9]+)+)[\s/>]`)
+)
+
+func (p *SFCProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := filepath.Ext(path)
+	dna := &core.FileDNA{
+		Path:     path,
+		Language: strings.TrimPrefix(ext, "."),
+		Imports:  []string{},
+		Exports:  []string{},
+		Metadata: make(map[string]interface{}),
+	}
+
+	filename := filepath.Base(path)
+	dna.Package = strings.TrimSuffix(filename, ext)
+
+	var basePackage string
+	dna.PackagePath, basePackage = jstsModulePath(path)
+
+	// A component is imported under its file name, so export that as its symbol.
+	dna.Exports = append(dna.Exports, dna.Package)
+
+	// <script> and <script setup> blocks are analyzed as plain JS/TS modules.
+	resolve := p.Script.resolver(path, basePackage)
+	scope := &sfcScope{bindings: make(map[string]string), registered: make(map[string]string)}
+	for _, match := range sfcScriptRe.FindAllSubmatch(content, -1) {
+		parser := sitter.NewParser()
+		parser.SetLanguage(javascript.GetLanguage())
+		if lang := sfcLangRe.FindSubmatch(match[1]); lang != nil {
+			switch string(lang[1]) {
+			case "ts":
+				parser.SetLanguage(typescript.GetLanguage())
+				dna.Metadata["scriptLang"] = "ts"
+			case "tsx":
+				parser.SetLanguage(tsx.GetLanguage())
+				dna.Metadata["scriptLang"] = "tsx"
+			}
+		}
+		script := match[2]
+		tree, _ := parser.ParseCtx(context.Background(), nil, script)
+		walkJSTSTree(tree.RootNode(), script, dna, resolve)
+		scope.collect(tree.RootNode(), script, resolve)
+	}
+
+	// Everything else (minus styles and comments) is template markup.
+	template := sfcScriptRe.ReplaceAll(content, nil)
+	template = sfcStyleRe.ReplaceAll(template, nil)
+	template = sfcCommentRe.ReplaceAll(template, nil)
+
+	seen := make(map[string]bool)
+	for _, match := range sfcComponentRe.FindAllSubmatch(template, -1) {
+		name := string(match[1])
+		if strings.Contains(name, "-") {
+			// <invoice-row> refers to the InvoiceRow component.
+			name = camelize(strings.ReplaceAll(name, "-", "_"))
+		}
+		if name == dna.Package {
+			continue
+		}
+		dna.Uses = appendUnique(dna.Uses, seen, scope.resolve(name))
+	}
+
+	return dna, nil
+}
+
+// sfcScope holds what the <script> blocks bind component names to.
+type sfcScope struct {
+	bindings   map[string]string // local name -> module path, or "module.Symbol" for named imports
+	registered map[string]string // name registered under `components:` -> local name
+}
+
+// collect records import bindings and `components: {...}` registrations.
+func (s *sfcScope) collect(node *sitter.Node, source []byte, resolve func(string) string) {
+	switch node.Type() {
+	case "import_statement":
+		clause, spec := childOfType(node, "import_clause"), node.ChildByFieldName("source")
+		if clause == nil || spec == nil {
+			return
+		}
+		module := resolve(unquote(spec.Content(source)))
+		for i := 0; i < int(clause.ChildCount()); i++ {
+			switch child := clause.Child(i); child.Type() {
+			case "identifier":
+				s.bindings[child.Content(source)] = module
+			case "namespace_import":
+				if name := childOfType(child, "identifier"); name != nil {
+					s.bindings[name.Content(source)] = module
+				}
+			case "named_imports":
+				for j := 0; j < int(child.ChildCount()); j++ {
+					specifier := child.Child(j)
+					name := specifier.ChildByFieldName("name")
+					if specifier.Type() != "import_specifier" || name == nil {
+						continue
+					}
+					local := name
+					if alias := specifier.ChildByFieldName("alias"); alias != nil {
+						local = alias
+					}
+					s.bindings[local.Content(source)] = module + "." + name.Content(source)
+				}
+			}
+		}
+		return
+	case "pair":
+		if key := node.ChildByFieldName("key"); key != nil && key.Content(source) == "components" {
+			if value := node.ChildByFieldName("value"); value != nil && value.Type() == "object" {
+				s.register(value, source)
+				return
+			}
+		}
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		s.collect(node.Child(i), source, resolve)
+	}
+}
+
+// register records the entries of a `components:` object.
+func (s *sfcScope) register(object *sitter.Node, source []byte) {
+	for i := 0; i < int(object.ChildCount()); i++ {
+		switch entry := object.Child(i); entry.Type() {
+		case "shorthand_property_identifier":
+			s.registered[entry.Content(source)] = entry.Content(source)
+		case "pair":
+			key, value := entry.ChildByFieldName("key"), entry.ChildByFieldName("value")
+			if key != nil && value != nil && value.Type() == "identifier" {
+				name := camelize(strings.ReplaceAll(unquote(key.Content(source)), "-", "_"))
+				s.registered[name] = value.Content(source)
+			}
+		}
+	}
+}
+
+// resolve maps a template tag name (e.g. "InvoiceRow" or "UI.Button") to the
+// module or symbol the script binds it to, falling back to the bare name.
+func (s *sfcScope) resolve(name string) string {
+	first, rest, dotted := strings.Cut(name, ".")
+	if local, ok := s.registered[first]; ok {
+		first = local
+	}
+	target, ok := s.bindings[first]
+	if !ok {
+		return name
+	}
+	if dotted {
+		return target + "." + rest
+	}
+	return target
+}
+
+func init() {
+	provider := &SFCProvider{Script: jstsProvider}
+	Register(".vue", provider)
+	Register(".svelte", provider)
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSFCProviderTemplateComponents(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"components/InvoiceRow.vue": "<template><tr></tr></template>\n",
+		"components/Badge.vue":      "<template><span></span></template>\n",
+		"components/Card.svelte":    "<div class=\"card\"><slot /></div>\n",
+		"Invoice.vue": `<script setup lang="ts">
+import InvoiceRow from './components/InvoiceRow.vue'
+</script>
+
+<template>
+  <!-- <Card/> is commented out -->
+  <invoice-row v-for="line in lines" />
+  <Badge />
+</template>
+`,
+		"Legacy.vue": `<template><line-item /></template>
+
+<script>
+import Row from './components/InvoiceRow.vue'
+export default { components: { 'line-item': Row } }
+</script>
+`,
+		"Panel.vue": `<script lang="tsx">
+import Badge from './components/Badge.vue'
+const render = () => <Badge />
+</script>
+`,
+		"App.svelte": `<script lang="ts">
+  import Card from './components/Card.svelte'
+</script>
+
+<Card>hello</Card>
+`,
+	})
+
+	files := []string{
+		"components/InvoiceRow.vue", "components/Badge.vue", "components/Card.svelte",
+		"Invoice.vue", "Legacy.vue", "Panel.vue", "App.svelte",
+	}
+	eng := ingestAll(t, &SFCProvider{Script: &JSTSProvider{}}, root, files...)
+
+	invoice := eng.FileMap[filepath.Join(root, "Invoice.vue")]
+	row := eng.FileMap[filepath.Join(root, "components/InvoiceRow.vue")]
+	if !slices.Contains(invoice.Uses, row.PackagePath) || slices.Contains(invoice.Uses, "InvoiceRow") {
+		t.Errorf("expected <invoice-row> to resolve through the script import, got %v", invoice.Uses)
+	}
+	if legacy := eng.FileMap[filepath.Join(root, "Legacy.vue")]; !slices.Equal(legacy.Uses, []string{row.PackagePath}) {
+		t.Errorf("expected <line-item> to resolve through components:, got %v", legacy.Uses)
+	}
+	if slices.Contains(invoice.Uses, "Card") {
+		t.Errorf("components in template comments are not uses: %v", invoice.Uses)
+	}
+	if got := eng.FileMap[filepath.Join(root, "Panel.vue")].Metadata["scriptLang"]; got != "tsx" {
+		t.Errorf("expected a tsx script block, got %v", got)
+	}
+
+	for _, edge := range [][2]string{
+		{"components/InvoiceRow.vue", "Invoice.vue"}, // <script setup> import
+		{"components/Badge.vue", "Invoice.vue"},      // bare-name fallback
+		{"components/InvoiceRow.vue", "Legacy.vue"},  // components: registration
+		{"components/Badge.vue", "Panel.vue"},
+		{"components/Card.svelte", "App.svelte"},
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+}
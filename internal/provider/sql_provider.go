@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/sql"
+)
+
+// SQLProvider implements the MultiProvider interface for SQL schema and
+// migration files.
+//
+// Every CREATE TABLE and CREATE VIEW becomes its own node. Tables import the
+// tables their foreign keys reference, and views import the relations they
+// select from. An ALTER TABLE adds its foreign keys to the table node when the
+// same file creates the table; otherwise (a later migration) it becomes an
+// "alter" node importing the table it alters. Table names are matched through
+// FileDNA.Provides under a "table:" prefix, so they never collide with code
+// symbols of the same name.
+type SQLProvider struct{}
+
+// Ensure SQLProvider implements MultiProvider.
+var _ MultiProvider = (*SQLProvider)(nil)
+
+// ParseFile summarises a SQL file as a single schema node that exports its
+// tables and views.
+func (p *SQLProvider) ParseFile(path string) (*core.FileDNA, error) {
+	nodes, err := p.ParseFileNodes(path)
+	if err != nil {
+		return nil, err
+	}
+	return fileSummary(path, "sql", "schema", nodes), nil
+}
+
+func (p *SQLProvider) ParseFileNodes(path string) ([]*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(sql.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+
+	var nodes []*core.FileDNA
+	byName := make(map[string]*core.FileDNA)
+	root := tree.RootNode()
+	for i := 0; i < int(root.ChildCount()); i++ {
+		statement := root.Child(i)
+		if statement.Type() != "statement" || statement.ChildCount() == 0 {
+			continue
+		}
+		stmt := statement.Child(0)
+
+		var kind string
+		switch stmt.Type() {
+		case "create_table":
+			kind = "table"
+		case "create_view", "create_materialized_view":
+			kind = "view"
+		case "alter_table":
+			kind = "alter"
+		default:
+			continue
+		}
+
+		nameNode := childOfType(stmt, "object_reference")
+		if nameNode == nil {
+			continue
+		}
+		name := sqlName(nameNode, content)
+
+		dna, ok := byName[name]
+		if !ok {
+			dna = &core.FileDNA{
+				Path:     path + "/" + name,
+				Language: "sql",
+				Kind:     kind,
+				Package:  name,
+				Imports:  []string{},
+				Exports:  []string{},
+				Metadata: make(map[string]interface{}),
+			}
+			if kind == "alter" {
+				// An ALTER of a table created in another migration depends on it.
+				dna.Imports = append(dna.Imports, "table:"+name)
+			}
+			byName[name] = dna
+			nodes = append(nodes, dna)
+		}
+		if kind != "alter" && dna.PackagePath == "" {
+			// The first CREATE defines the node, even after an earlier ALTER.
+			dna.Kind = kind
+			dna.PackagePath = "table:" + name
+			dna.Provides = sqlProvides(name)
+			dna.Imports = slices.DeleteFunc(dna.Imports, func(imp string) bool { return imp == "table:"+name })
+		}
+
+		var refs []string
+		if kind == "view" {
+			refs = sqlViewSources(stmt, content)
+		} else {
+			refs = sqlForeignKeys(stmt, content)
+		}
+		for _, ref := range refs {
+			if ref != name && !slices.Contains(dna.Imports, "table:"+ref) {
+				dna.Imports = append(dna.Imports, "table:"+ref)
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// sqlProvides returns the keys a table is referenced by: its name as written
+// and, for a schema-qualified name, the bare table name.
+func sqlProvides(name string) []string {
+	provides := []string{"table:" + name}
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		provides = append(provides, "table:"+name[idx+1:])
+	}
+	return provides
+}
+
+// sqlForeignKeys collects the tables named after REFERENCES in a statement.
+func sqlForeignKeys(node *sitter.Node, sourceCode []byte) []string {
+	var refs []string
+	afterReferences := false
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		switch {
+		case child.Type() == "keyword_references":
+			afterReferences = true
+			continue
+		case afterReferences && child.Type() == "object_reference":
+			refs = append(refs, sqlName(child, sourceCode))
+		default:
+			refs = append(refs, sqlForeignKeys(child, sourceCode)...)
+		}
+		afterReferences = false
+	}
+	return refs
+}
+
+// sqlViewSources collects the relations a view selects from, skipping the
+// names of its common table expressions.
+func sqlViewSources(node *sitter.Node, sourceCode []byte) []string {
+	ctes := make(map[string]bool)
+	var refs []string
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		switch n.Type() {
+		case "cte":
+			if ident := childOfType(n, "identifier"); ident != nil {
+				ctes[sqlName(ident, sourceCode)] = true
+			}
+		case "relation":
+			if ref := childOfType(n, "object_reference"); ref != nil {
+				refs = append(refs, sqlName(ref, sourceCode))
+			}
+		}
+		for i := 0; i < int(n.ChildCount()); i++ {
+			walk(n.Child(i))
+		}
+	}
+	walk(node)
+
+	var sources []string
+	for _, ref := range refs {
+		if !ctes[ref] && !slices.Contains(sources, ref) {
+			sources = append(sources, ref)
+		}
+	}
+	return sources
+}
+
+// sqlName normalises an object reference: identifier quoting is removed and
+// unquoted names are folded to lower case, as the database does.
+func sqlName(node *sitter.Node, sourceCode []byte) string {
+	var parts []string
+	for _, part := range strings.Split(node.Content(sourceCode), ".") {
+		part = strings.TrimSpace(part)
+		if len(part) >= 2 && strings.ContainsRune("\"`[", rune(part[0])) {
+			parts = append(parts, part[1:len(part)-1])
+		} else {
+			parts = append(parts, strings.ToLower(part))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func init() {
+	Register(".sql", &SQLProvider{})
+}
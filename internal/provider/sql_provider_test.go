@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ritiksrivastava/archhelix/internal/engine"
+)
+
+func TestSQLProviderLinksTables(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"schema.sql": `CREATE TABLE public.users (id serial PRIMARY KEY);
+CREATE TABLE "orders" (
+  id int PRIMARY KEY,
+  user_id int REFERENCES users(id)
+);
+CREATE VIEW big_orders AS SELECT o.id FROM orders o JOIN users u ON u.id = o.user_id;
+CREATE TABLE admins (id int PRIMARY KEY);
+ALTER TABLE orders ADD CONSTRAINT fk_archiver FOREIGN KEY (archived_by) REFERENCES admins(id);
+`,
+		"migrations/002_coupons.sql": `CREATE TABLE coupons (id int PRIMARY KEY);
+ALTER TABLE orders ADD CONSTRAINT fk_coupon FOREIGN KEY (coupon_id) REFERENCES coupons(id);
+`,
+	})
+
+	p := &SQLProvider{}
+	eng := engine.New()
+	for _, name := range []string{"schema.sql", "migrations/002_coupons.sql"} {
+		nodes, err := p.ParseFileNodes(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("ParseFileNodes(%s) failed: %v", name, err)
+		}
+		for _, dna := range nodes {
+			eng.IngestFileDNA(dna)
+		}
+	}
+	eng.LinkDependencies()
+
+	orders := eng.FileMap[filepath.Join(root, "schema.sql/orders")]
+	if orders == nil || orders.Kind != "table" {
+		t.Fatalf("expected a table node for orders, got %+v", orders)
+	}
+
+	// An ALTER in the file creating the table merges into the table node; one
+	// in a later migration is a node of its own, depending on the table it
+	// alters and on the tables its foreign keys reference.
+	if alter := eng.FileMap[filepath.Join(root, "migrations/002_coupons.sql/orders")]; alter == nil || alter.Kind != "alter" {
+		t.Fatalf("expected an alter node for orders in the migration, got %+v", alter)
+	}
+
+	for _, edge := range [][2]string{
+		{"schema.sql/public.users", "schema.sql/orders"},
+		{"schema.sql/orders", "schema.sql/big_orders"},
+		{"schema.sql/public.users", "schema.sql/big_orders"},
+		{"schema.sql/admins", "schema.sql/orders"},
+		{"schema.sql/orders", "migrations/002_coupons.sql/orders"},
+		{"migrations/002_coupons.sql/coupons", "migrations/002_coupons.sql/orders"},
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+	if hasPathEdge(eng, root, "migrations/002_coupons.sql/coupons", "schema.sql/orders") {
+		t.Errorf("a later migration's foreign key should not rewrite the table created earlier")
+	}
+}
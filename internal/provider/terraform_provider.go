@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ritiksrivastava/archhelix/internal/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/hcl"
+)
+
+// TerraformProvider implements the Provider interface for Terraform files.
+//
+// A Terraform module is a directory, so every .tf file uses its absolute
+// directory as PackagePath and exports the addresses it declares
+// ("aws_s3_bucket.logs", "data.x.y", "module.vpc", "var.cidr", "local.env",
+// "output.id"). References become Uses qualified by the same directory, which
+// links files of one module to each other. A local module `source` is an
+// import of the module's directory, and `module.vpc.id` is also a use of the
+// "output.id" that module exports.
+type TerraformProvider struct {
+	mu      sync.Mutex
+	modules map[string]map[string]string // directory -> module name -> source
+}
+
+// Ensure TerraformProvider implements Provider.
+var _ Provider = (*TerraformProvider)(nil)
+
+func (p *TerraformProvider) ParseFile(path string) (*core.FileDNA, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.ToSlash(filepath.Dir(absPath))
+
+	dna := &core.FileDNA{
+		Path:        path,
+		Language:    "terraform",
+		Package:     filepath.Base(dir),
+		PackagePath: dir,
+		Imports:     []string{},
+		Exports:     []string{},
+		Metadata:    make(map[string]interface{}),
+	}
+
+	root := parseHCL(content)
+	for _, block := range hclBlocks(root) {
+		blockType, labels := hclBlockHeader(block, content)
+		switch {
+		case blockType == "resource" && len(labels) == 2:
+			dna.Exports = append(dna.Exports, labels[0]+"."+labels[1])
+		case blockType == "data" && len(labels) == 2:
+			dna.Exports = append(dna.Exports, "data."+labels[0]+"."+labels[1])
+		case blockType == "variable" && len(labels) == 1:
+			dna.Exports = append(dna.Exports, "var."+labels[0])
+		case blockType == "output" && len(labels) == 1:
+			dna.Exports = append(dna.Exports, "output."+labels[0])
+		case blockType == "locals":
+			for _, name := range hclAttributeNames(block, content) {
+				dna.Exports = append(dna.Exports, "local."+name)
+			}
+		case blockType == "module" && len(labels) == 1:
+			dna.Exports = append(dna.Exports, "module."+labels[0])
+			if source := hclStringAttribute(block, content, "source"); source != "" {
+				dna.Imports = append(dna.Imports, resolveTerraformSource(dir, source))
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var walk func(node *sitter.Node)
+	walk = func(node *sitter.Node) {
+		if node.Type() == "expression" {
+			for _, use := range p.referenceUses(dir, hclReference(node, content)) {
+				dna.Uses = appendUnique(dna.Uses, seen, use)
+			}
+		}
+		for i := 0; i < int(node.ChildCount()); i++ {
+			walk(node.Child(i))
+		}
+	}
+	walk(root)
+
+	return dna, nil
+}
+
+// referenceUses maps a reference such as ["module", "vpc", "id"] to the
+// symbols it uses.
+func (p *TerraformProvider) referenceUses(dir string, ref []string) []string {
+	if len(ref) < 2 {
+		return nil
+	}
+	switch ref[0] {
+	case "var", "local":
+		return []string{dir + "." + ref[0] + "." + ref[1]}
+	case "module":
+		uses := []string{dir + ".module." + ref[1]}
+		if len(ref) >= 3 {
+			if source := p.moduleSource(dir, ref[1]); source != "" {
+				uses = append(uses, source+".output."+ref[2])
+			}
+		}
+		return uses
+	case "data":
+		if len(ref) >= 3 {
+			return []string{dir + ".data." + ref[1] + "." + ref[2]}
+		}
+	case "count", "each", "self", "path", "terraform":
+	default:
+		// Resource types are always prefixed by their provider ("aws_", "google_").
+		if strings.Contains(ref[0], "_") {
+			return []string{dir + "." + ref[0] + "." + ref[1]}
+		}
+	}
+	return nil
+}
+
+// moduleSource returns the resolved source of a module block declared in any
+// file of dir. The module map of each directory is read once and cached.
+func (p *TerraformProvider) moduleSource(dir, name string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.modules == nil {
+		p.modules = make(map[string]map[string]string)
+	}
+	sources, ok := p.modules[dir]
+	if !ok {
+		sources = make(map[string]string)
+		files, _ := filepath.Glob(filepath.Join(filepath.FromSlash(dir), "*.tf"))
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+			for _, block := range hclBlocks(parseHCL(content)) {
+				blockType, labels := hclBlockHeader(block, content)
+				if blockType != "module" || len(labels) != 1 {
+					continue
+				}
+				if source := hclStringAttribute(block, content, "source"); source != "" {
+					sources[labels[0]] = resolveTerraformSource(dir, source)
+				}
+			}
+		}
+		p.modules[dir] = sources
+	}
+	return sources[name]
+}
+
+// resolveTerraformSource turns a local module source into the absolute module
+// directory. Registry and VCS sources are returned unchanged.
+func resolveTerraformSource(dir, source string) string {
+	if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+		return filepath.ToSlash(filepath.Join(filepath.FromSlash(dir), source))
+	}
+	return source
+}
+
+func parseHCL(content []byte) *sitter.Node {
+	parser := sitter.NewParser()
+	parser.SetLanguage(hcl.GetLanguage())
+	tree, _ := parser.ParseCtx(context.Background(), nil, content)
+	return tree.RootNode()
+}
+
+// hclBlocks returns the top-level blocks of a configuration file.
+func hclBlocks(root *sitter.Node) []*sitter.Node {
+	var blocks []*sitter.Node
+	if body := childOfType(root, "body"); body != nil {
+		for i := 0; i < int(body.ChildCount()); i++ {
+			if child := body.Child(i); child.Type() == "block" {
+				blocks = append(blocks, child)
+			}
+		}
+	}
+	return blocks
+}
+
+// hclBlockHeader returns a block's type and labels, e.g. "resource" and
+// ["aws_s3_bucket", "logs"].
+func hclBlockHeader(block *sitter.Node, sourceCode []byte) (string, []string) {
+	var blockType string
+	var labels []string
+	for i := 0; i < int(block.ChildCount()); i++ {
+		switch child := block.Child(i); child.Type() {
+		case "identifier":
+			if blockType == "" {
+				blockType = child.Content(sourceCode)
+			} else {
+				labels = append(labels, child.Content(sourceCode))
+			}
+		case "string_lit":
+			labels = append(labels, hclString(child, sourceCode))
+		}
+	}
+	return blockType, labels
+}
+
+func hclAttributeNames(block *sitter.Node, sourceCode []byte) []string {
+	var names []string
+	if body := childOfType(block, "body"); body != nil {
+		for i := 0; i < int(body.ChildCount()); i++ {
+			if attr := body.Child(i); attr.Type() == "attribute" {
+				if ident := childOfType(attr, "identifier"); ident != nil {
+					names = append(names, ident.Content(sourceCode))
+				}
+			}
+		}
+	}
+	return names
+}
+
+// hclStringAttribute returns the value of a block attribute set to a plain
+// string literal, or "" if there is none.
+func hclStringAttribute(block *sitter.Node, sourceCode []byte, name string) string {
+	body := childOfType(block, "body")
+	if body == nil {
+		return ""
+	}
+	for i := 0; i < int(body.ChildCount()); i++ {
+		attr := body.Child(i)
+		if attr.Type() != "attribute" {
+			continue
+		}
+		if ident := childOfType(attr, "identifier"); ident == nil || ident.Content(sourceCode) != name {
+			continue
+		}
+		if expr := childOfType(attr, "expression"); expr != nil {
+			if literal := childOfType(expr, "literal_value"); literal != nil {
+				if str := childOfType(literal, "string_lit"); str != nil {
+					return hclString(str, sourceCode)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func hclString(node *sitter.Node, sourceCode []byte) string {
+	if literal := childOfType(node, "template_literal"); literal != nil {
+		return literal.Content(sourceCode)
+	}
+	return ""
+}
+
+// hclReference returns the traversal an expression starts with, e.g.
+// ["aws_s3_bucket", "logs", "arn"] for `aws_s3_bucket.logs.arn`.
+func hclReference(expr *sitter.Node, sourceCode []byte) []string {
+	if expr.ChildCount() == 0 || expr.Child(0).Type() != "variable_expr" {
+		return nil
+	}
+	ref := []string{expr.Child(0).Content(sourceCode)}
+	for i := 1; i < int(expr.ChildCount()); i++ {
+		attr := expr.Child(i)
+		if attr.Type() != "get_attr" {
+			break
+		}
+		if ident := childOfType(attr, "identifier"); ident != nil {
+			ref = append(ref, ident.Content(sourceCode))
+		}
+	}
+	return ref
+}
+
+func init() {
+	Register(".tf", &TerraformProvider{})
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestTerraformProviderModulesAndReferences(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"modules/vpc/main.tf": `resource "aws_vpc" "this" {
+  cidr_block = var.cidr
+}
+`,
+		"modules/vpc/variables.tf": "variable \"cidr\" {}\n",
+		"modules/vpc/outputs.tf": `output "id" {
+  value = aws_vpc.this.id
+}
+`,
+		"live/main.tf": `module "network" {
+  source = "../modules/vpc"
+  cidr   = local.cidr
+}
+
+module "registry" {
+  source = "terraform-aws-modules/s3-bucket/aws"
+}
+
+resource "aws_instance" "web" {
+  subnet_id = "${module.network.id}"
+  ami       = data.aws_ami.ubuntu.id
+}
+`,
+		"live/data.tf": `data "aws_ami" "ubuntu" {
+  most_recent = true
+}
+
+locals {
+  cidr = "10.0.0.0/16"
+}
+`,
+	})
+
+	files := []string{"modules/vpc/main.tf", "modules/vpc/variables.tf", "modules/vpc/outputs.tf", "live/main.tf", "live/data.tf"}
+	eng := ingestAll(t, &TerraformProvider{}, root, files...)
+
+	live := eng.FileMap[filepath.Join(root, "live/main.tf")]
+	if want := []string{filepath.ToSlash(filepath.Join(root, "modules/vpc")), "terraform-aws-modules/s3-bucket/aws"}; !slices.Equal(live.Imports, want) {
+		t.Errorf("expected module sources %v, got %v", want, live.Imports)
+	}
+	for _, export := range []string{"module.network", "aws_instance.web"} {
+		if !slices.Contains(live.Exports, export) {
+			t.Errorf("expected export %q in %v", export, live.Exports)
+		}
+	}
+
+	for _, edge := range [][2]string{
+		{"modules/vpc/variables.tf", "modules/vpc/main.tf"}, // var.cidr
+		{"modules/vpc/main.tf", "modules/vpc/outputs.tf"},   // resource reference
+		{"live/data.tf", "live/main.tf"},                    // data source and local
+		{"modules/vpc/outputs.tf", "live/main.tf"},          // module output
+	} {
+		if !hasPathEdge(eng, root, edge[0], edge[1]) {
+			t.Errorf("expected edge %s -> %s", edge[0], edge[1])
+		}
+	}
+}
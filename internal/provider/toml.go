@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/toml"
+)
+
+// readTOML parses a TOML document (Cargo.toml, pyproject.toml, ...) into a flat
+// map keyed by dotted key paths such as "package.name". String values are
+// unquoted, arrays become []string and any other scalar keeps its literal text.
+// Entries of table arrays ([[bin]]) share the key of their table, so the last
+// entry wins.
+func readTOML(content []byte) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(toml.GetLanguage())
+	tree, err := parser.ParseCtx(context.Background(), nil, content)
+	if err != nil {
+		return values
+	}
+
+	root := tree.RootNode()
+	for i := 0; i < int(root.ChildCount()); i++ {
+		child := root.Child(i)
+		switch child.Type() {
+		case "pair":
+			readTOMLPair(child, content, "", values)
+		case "table", "table_array_element":
+			prefix := ""
+			for j := 0; j < int(child.ChildCount()); j++ {
+				entry := child.Child(j)
+				switch entry.Type() {
+				case "bare_key", "quoted_key", "dotted_key":
+					prefix = tomlKey(entry, content)
+				case "pair":
+					readTOMLPair(entry, content, prefix, values)
+				}
+			}
+		}
+	}
+
+	return values
+}
+
+func readTOMLPair(node *sitter.Node, content []byte, prefix string, values map[string]interface{}) {
+	if node.ChildCount() < 3 {
+		return
+	}
+	key := tomlKey(node.Child(0), content)
+	if prefix != "" {
+		key = prefix + "." + key
+	}
+
+	value := node.Child(int(node.ChildCount()) - 1)
+	switch value.Type() {
+	case "inline_table":
+		for i := 0; i < int(value.ChildCount()); i++ {
+			if pair := value.Child(i); pair.Type() == "pair" {
+				readTOMLPair(pair, content, key, values)
+			}
+		}
+	case "array":
+		items := []string{}
+		for i := 0; i < int(value.ChildCount()); i++ {
+			item := value.Child(i)
+			if item.IsNamed() {
+				items = append(items, tomlString(item.Content(content)))
+			}
+		}
+		values[key] = items
+	default:
+		values[key] = tomlString(value.Content(content))
+	}
+}
+
+func tomlKey(node *sitter.Node, content []byte) string {
+	if node.Type() == "dotted_key" {
+		var parts []string
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if part := node.Child(i); part.IsNamed() {
+				parts = append(parts, tomlKey(part, content))
+			}
+		}
+		return strings.Join(parts, ".")
+	}
+	return tomlString(node.Content(content))
+}
+
+func tomlString(s string) string {
+	return strings.Trim(s, "\"'")
+}
+
+// tomlStrings returns the value at key as a list, accepting a single string too.
+func tomlStrings(values map[string]interface{}, key string) []string {
+	switch v := values[key].(type) {
+	case []string:
+		return v
+	case string:
+		return []string{v}
+	}
+	return nil
+}
+
+// tomlValue returns the value at key if it is a scalar.
+func tomlValue(values map[string]interface{}, key string) string {
+	if v, ok := values[key].(string); ok {
+		return v
+	}
+	return ""
+}
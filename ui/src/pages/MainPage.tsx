@@ -78,7 +78,7 @@ const MainPage = () => {
     // Unified effect to handle graph data updates from Redux
     useEffect(() => {
         const collapsedFoldersMap = openFolderState.folders || {};
-        const { nodes: freshNodes, edges: freshEdges } = getUpdatedNodesAndEdges(collapsedFoldersMap);
+        const { nodes: freshNodes, edges: freshEdges } = getUpdatedNodesAndEdges(collapsedFoldersMap, editorState.hiddenEdgeKinds);
 
         if (freshNodes.length === 0) return;
 
@@ -162,7 +162,7 @@ const MainPage = () => {
             setEdges(freshEdges);
             setIsolatedNodeId(null);
         }
-    }, [openFolderState.folders, searchedNodeId, setNodes, setEdges]);
+    }, [openFolderState.folders, editorState.hiddenEdgeKinds, searchedNodeId, setNodes, setEdges]);
 
     const startResizing = useCallback(() => setIsResizing(true), []);
     const stopResizing = useCallback(() => setIsResizing(false), []);
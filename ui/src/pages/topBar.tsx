@@ -2,9 +2,18 @@ import { Box, Button, Text, Image, TextInput } from "grommet"
 import { Search } from "grommet-icons";
 import { useState } from "react";
 import { useSelector, useDispatch } from "react-redux";
-import { changeNote } from '../store/slice/settingSlice'
+import { changeNote, toggleEdgeKind } from '../store/slice/settingSlice'
 import { setSearchedNodeId } from '../store/slice/searchSlice';
-import mainLogo from '../assets/mainIcon.png'; const SideBar = () => {
+import mainLogo from '../assets/mainIcon.png';
+
+// Edge kinds that can be hidden from the graph: type-only imports, and the
+// package __init__ files a re-exported symbol was imported through.
+const hideableEdgeKinds = [
+    { kind: 'type', label: 'type links' },
+    { kind: 'barrel', label: 'barrel links' },
+];
+
+const SideBar = () => {
     const dispatch = useDispatch();
 
     const [hoveredButton, setHoveredButton] = useState('null');
@@ -79,6 +88,11 @@ import mainLogo from '../assets/mainIcon.png'; const SideBar = () => {
             </Box>
 
             <Box style={{ position: 'absolute', top: '25px', right: '25px', zIndex: 2, }} direction="row" gap="medium">
+                {hideableEdgeKinds.map(({ kind, label }) => (
+                    <Button key={kind} style={{ visibility: splitState ? 'hidden' : 'visible' }} onClick={() => dispatch(toggleEdgeKind(kind))} onMouseEnter={() => setHoveredButton(kind)} onMouseLeave={() => setHoveredButton('null')}>
+                        <Text weight='bold' color={hoveredButton === kind ? '#444' : '#666e78'} >{state.hiddenEdgeKinds.includes(kind) ? `Show ${label}` : `Hide ${label}`}</Text>
+                    </Button>
+                ))}
                 <Button style={{ visibility: splitState ? 'hidden' : 'visible' }} onClick={openNotes} onMouseEnter={() => setHoveredButton('Notes')} onMouseLeave={() => setHoveredButton('null')}>
                     <Text weight='bold' color={hoveredButton === 'Notes' ? '#444' : '#666e78'} >Notes</Text>
                 </Button>
@@ -3,12 +3,14 @@ import { createSlice } from "@reduxjs/toolkit";
 export interface IsettingState{
     note: boolean,
     code: boolean,
+    hiddenEdgeKinds: string[],
 }
 
 
 const initialState: IsettingState = {
     note: false,
     code: false,
+    hiddenEdgeKinds: [],
 }
 
 export const settingSlice = createSlice({
@@ -26,12 +28,18 @@ export const settingSlice = createSlice({
         closeEditor: (state, action) =>{
             state.code = false
             state.note = false
+        },
+        toggleEdgeKind: (state, action) =>{
+            const kind = action.payload as string
+            state.hiddenEdgeKinds = state.hiddenEdgeKinds.includes(kind)
+                ? state.hiddenEdgeKinds.filter(k => k !== kind)
+                : [...state.hiddenEdgeKinds, kind]
         }
 
     }
 })
 
 
-export const {changeNote, changeCode, closeEditor} = settingSlice.actions
+export const {changeNote, changeCode, closeEditor, toggleEdgeKind} = settingSlice.actions
 
 export default settingSlice.reducer
\ No newline at end of file
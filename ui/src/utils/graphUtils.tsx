@@ -7,12 +7,16 @@ import { setOpenFolders } from "../store/slice/openFolderSlice";
 interface GoNode {
     ID: string;
     Label: string;
+    Kind?: string;
+    Group?: string;
     DependencyCount?: number;
 }
 
 interface GoEdge {
     Source: string;
     Target: string;
+    Kind?: string;
+    Inferred?: boolean;
 }
 
 interface GoGraph {
@@ -31,6 +35,7 @@ interface NodeTree {
     x: number;
     y: number;
     color?: string;
+    module?: string; // ID of the build module node this folder is the box of
 }
 
 const PADDING = 40;
@@ -107,6 +112,30 @@ function layoutTree(node: NodeTree, collapsedFoldersMap: Record<string, boolean>
     node.height = currentY;
 }
 
+// markModuleFolders turns the folder enclosing every member of a Group (e.g.
+// the directory of a pom.xml or build.gradle) into the box of that module.
+function markModuleFolders(nodeData: GoNode[], idToTree: { [id: string]: NodeTree }) {
+    const members: Record<string, string[][]> = {};
+    nodeData.forEach(goNode => {
+        if (goNode.Group) {
+            (members[goNode.Group] = members[goNode.Group] || []).push(goNode.ID.split('/').slice(0, -1));
+        }
+    });
+
+    Object.entries(members).forEach(([group, folders]) => {
+        let common = folders[0];
+        folders.forEach(parts => {
+            let i = 0;
+            while (i < common.length && i < parts.length && common[i] === parts[i]) i++;
+            common = common.slice(0, i);
+        });
+        const folder = idToTree[common.join('/')];
+        if (folder && folder.isFolder && !folder.module) {
+            folder.module = group;
+        }
+    });
+}
+
 const processGraphData = async (goGraph: GoGraph, structureData: any[] = []) => {
     try {
         if (!goGraph || !goGraph.Nodes || !goGraph.Edges) {
@@ -151,6 +180,7 @@ const processGraphData = async (goGraph: GoGraph, structureData: any[] = []) =>
             idToTree[goNode.ID] = fileNode;
         });
 
+        markModuleFolders(nodeData, idToTree);
         assignColors(treeRoot);
         cachedTreeRoot = treeRoot;
 
@@ -187,13 +217,15 @@ const processGraphData = async (goGraph: GoGraph, structureData: any[] = []) =>
     }
 };
 
-export const getUpdatedNodesAndEdges = (collapsedFoldersMap: Record<string, boolean>) => {
+export const getUpdatedNodesAndEdges = (collapsedFoldersMap: Record<string, boolean>, hiddenEdgeKinds: string[] = []) => {
     if (!cachedTreeRoot) return { nodes: [], edges: [], nodesDictionary: {} };
 
     // Recompute layout for entire tree according to state
     layoutTree(cachedTreeRoot, collapsedFoldersMap);
 
-    return computeNodesAndEdgesState(cachedTreeRoot, cachedEdgesData, collapsedFoldersMap);
+    // Edges of hidden kinds (e.g. type-only imports) are left out entirely
+    const edgeData = cachedEdgesData.filter(edge => !hiddenEdgeKinds.includes(edge.Kind || ''));
+    return computeNodesAndEdgesState(cachedTreeRoot, edgeData, collapsedFoldersMap);
 };
 
 const computeNodesAndEdgesState = (treeRoot: NodeTree, edgeData: GoEdge[], collapsedFoldersMap: Record<string, boolean>) => {
@@ -219,7 +251,7 @@ const computeNodesAndEdgesState = (treeRoot: NodeTree, edgeData: GoEdge[], colla
                 position: { x: treeNode.x, y: treeNode.y },
                 parentNode: parentId,
                 style: { width: treeNode.width, height: treeNode.height, zIndex: -1 },
-                data: { label: treeNode.name, color: treeNode.color, icon: true, originalWidth: treeNode.width, originalHeight: treeNode.height },
+                data: { label: treeNode.name, color: treeNode.color, icon: true, module: treeNode.module, originalWidth: treeNode.width, originalHeight: treeNode.height },
             };
             nodes.push(folderNode);
             nodesDictionary[folderNode.id] = folderNode;
@@ -295,7 +327,11 @@ const computeNodesAndEdgesState = (treeRoot: NodeTree, edgeData: GoEdge[], colla
             },
             style: {
                 strokeWidth: 2,
-                stroke: '#A9A9A9',
+                // Inferred edges (e.g. dynamic imports) are drawn lighter.
+                stroke: edge.Inferred ? '#D3D3D3' : '#A9A9A9',
+                // Membership edges (a file in a build target) are drawn dashed,
+                // other import kinds (type-only, optional, lazy, dynamic) dotted.
+                strokeDasharray: edge.Kind === 'contains' ? '4 4' : edge.Kind ? '1 4' : undefined,
             }
         };
     });
@@ -43,6 +43,11 @@ const CustomBox = (data: any) => {
           <Text size='medium' weight='bold' color="dark-2" className="truncate select-none font-sans">
             {data.data.label || data.id.split('/').pop()}
           </Text>
+          {data.data.module && (
+            <span className="rounded bg-indigo-200 px-1.5 text-xs font-semibold text-indigo-800 select-none" title={data.data.module}>
+              module
+            </span>
+          )}
         </div>
       </div>
 